@@ -0,0 +1,75 @@
+package di
+
+import (
+	"fmt"
+)
+
+// AuthorizeFunc is a policy that decides whether consumer can obtain target. Non-nil error
+// forbids the dependency and fails resolution.
+type AuthorizeFunc func(consumer NodeInfo, target NodeInfo) error
+
+// Authorize returns container option that registers resolution policy. The policy consulted on
+// each dependency edge: constructor arguments, injected fields, group members and targets of
+// di.Lazy[T] and provider functions. Child containers inherit policies of container.
+//
+//	container, err := di.New(
+//		di.Authorize(func(consumer di.NodeInfo, target di.NodeInfo) error {
+//			if target.Type == reflect.TypeOf(new(PrivateKey)) && consumer.Type.Elem().PkgPath() != "example.com/crypto" {
+//				return fmt.Errorf("private key available only for crypto module")
+//			}
+//			return nil
+//		}),
+//	)
+func Authorize(fn AuthorizeFunc) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.schema.authorizers = append(c.schema.authorizers, fn)
+		})
+	})
+}
+
+// authorize checks that consumer can obtain target by all registered policies.
+func (s *defaultSchema) authorize(consumer *node, target *node) error {
+	if len(s.authorizers) == 0 {
+		return nil
+	}
+	consumerInfo, targetInfo := consumer.info(), target.info()
+	for _, authorize := range s.authorizers {
+		if err := authorize(consumerInfo, targetInfo); err != nil {
+			return &authorizationError{consumer: consumer, target: target, err: err}
+		}
+	}
+	// target of lazy dependency or provider function obtained by consumer later, so it
+	// authorized together with dependency itself
+	if cmp, ok := target.compiler.(deferredCompiler); ok {
+		return s.authorize(consumer, cmp.deferred())
+	}
+	// group is not a consumer of its members, they obtained by consumer of group
+	if cmp, ok := target.compiler.(memberCompiler); ok {
+		for _, member := range cmp.members() {
+			if err := s.authorize(consumer, member); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// authorizationError is an error of authorization policy.
+type authorizationError struct {
+	consumer *node
+	target   *node
+	err      error
+}
+
+func (e *authorizationError) Error() string {
+	return fmt.Sprintf("%s is not authorized to obtain %s: %s", e.consumer, e.target, e.err)
+}
+
+func (e *authorizationError) Unwrap() error {
+	return e.err
+}
+
+func (e *authorizationError) Is(target error) bool {
+	return target == ErrNotAuthorized
+}
@@ -0,0 +1,141 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestAuthorize(t *testing.T) {
+	errForbidden := errors.New("forbidden")
+	denyMux := di.Authorize(func(consumer di.NodeInfo, target di.NodeInfo) error {
+		if target.Type == reflect.TypeOf(new(http.ServeMux)) && consumer.Tags["trusted"] != "true" {
+			return errForbidden
+		}
+		return nil
+	})
+
+	t.Run("policy consulted for constructor arguments", func(t *testing.T) {
+		var edges []string
+		c, err := di.New(
+			di.Authorize(func(consumer di.NodeInfo, target di.NodeInfo) error {
+				edges = append(edges, consumer.Type.String()+" -> "+target.Type.String())
+				return nil
+			}),
+			di.Provide(http.NewServeMux),
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, []string{"*http.Server -> *http.ServeMux"}, edges)
+	})
+
+	t.Run("forbidden constructor argument", func(t *testing.T) {
+		c, err := di.New(
+			denyMux,
+			di.Provide(http.NewServeMux),
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }, di.Tags{"trusted": "false"}),
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }, di.Tags{"trusted": "true"}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server, di.Tags{"trusted": "true"})
+		require.NoError(t, err)
+		err = c.Resolve(&server, di.Tags{"trusted": "false"})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, errForbidden))
+		require.Contains(t, err.Error(), "*http.Server[trusted:false] is not authorized to obtain *http.ServeMux: forbidden")
+	})
+
+	t.Run("forbidden target of lazy dependency", func(t *testing.T) {
+		c, err := di.New(
+			denyMux,
+			di.Provide(http.NewServeMux),
+			di.Provide(func(mux di.Lazy[*http.ServeMux]) *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, errForbidden))
+		require.Contains(t, err.Error(), "*http.Server is not authorized to obtain *http.ServeMux: forbidden")
+	})
+
+	t.Run("forbidden target of provider function", func(t *testing.T) {
+		c, err := di.New(
+			denyMux,
+			di.Provide(http.NewServeMux),
+			di.Provide(func(get func() (*http.ServeMux, error)) *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, errForbidden))
+		require.Contains(t, err.Error(), "*http.Server is not authorized to obtain *http.ServeMux: forbidden")
+	})
+
+	t.Run("group members authorized with consumer of group", func(t *testing.T) {
+		c, err := di.New(
+			denyMux,
+			di.Provide(http.NewServeMux, di.WithName("public")),
+			di.Provide(func(muxes []*http.ServeMux, named map[string]*http.ServeMux) *http.Server {
+				return &http.Server{Handler: named["public"]}
+			}, di.Tags{"trusted": "true"}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+	})
+
+	t.Run("forbidden group member", func(t *testing.T) {
+		c, err := di.New(
+			denyMux,
+			di.Provide(http.NewServeMux, di.Tags{"key": "public"}),
+			di.Provide(func(muxes di.Keyed[string, *http.ServeMux]) *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, errForbidden))
+		require.Contains(t, err.Error(), "*http.Server is not authorized to obtain *http.ServeMux[key:public]: forbidden")
+	})
+
+	t.Run("forbidden injected field", func(t *testing.T) {
+		type Handler struct {
+			di.Inject
+			Mux *http.ServeMux
+		}
+		c, err := di.New(
+			denyMux,
+			di.Provide(http.NewServeMux),
+			di.Provide(func() *Handler { return &Handler{} }),
+		)
+		require.NoError(t, err)
+		var handler *Handler
+		err = c.Resolve(&handler)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "*di_test.Handler is not authorized to obtain *http.ServeMux: forbidden")
+	})
+
+	t.Run("child inherits policy", func(t *testing.T) {
+		parent, err := di.New(
+			denyMux,
+			di.Provide(http.NewServeMux),
+		)
+		require.NoError(t, err)
+		child, err := parent.NewChild(
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.True(t, errors.Is(child.Resolve(&server), errForbidden))
+	})
+}
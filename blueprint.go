@@ -0,0 +1,89 @@
+package di
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// BlueprintVersion is a current version of blueprint format. It changes when blueprint
+// structure or hash calculation changes.
+const BlueprintVersion = 1
+
+// Blueprint is a serializable declarative part of container wiring: provided types, their tags,
+// interface bindings and provenance. It can be stored and compared with blueprint of running
+// binary to be sure that wiring matches reviewed one.
+//
+//	data, err := json.Marshal(container.Blueprint())
+//	if err != nil {
+//		// handle error
+//	}
+type Blueprint struct {
+	// Version is a blueprint format version.
+	Version int `json:"version"`
+	// Definitions is a sorted list of container definitions.
+	Definitions []BlueprintDefinition `json:"definitions"`
+}
+
+// BlueprintDefinition describes single provided type.
+type BlueprintDefinition struct {
+	// Type is a string representation of provided type.
+	Type string `json:"type"`
+	// Tags is a tags of provided type.
+	Tags Tags `json:"tags,omitempty"`
+	// Interfaces is a list of interfaces registered with di.As().
+	Interfaces []string `json:"interfaces,omitempty"`
+	// Provenance is a fully qualified name of function where type was provided, so
+	// definitions provided by functions with the same name from different packages differ.
+	Provenance string `json:"provenance,omitempty"`
+	// Sensitive is true if definition marked with di.Sensitive(). Tags of sensitive
+	// definition are redacted.
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// Hash returns stable hash of blueprint. Provenance does not affect the hash, so moving
+// di.Provide() between functions does not change it.
+func (b Blueprint) Hash() string {
+	h := sha256.New()
+	_, _ = h.Write([]byte{byte(b.Version)})
+	for _, def := range b.Definitions {
+		_, _ = h.Write([]byte(def.Type + def.Tags.String() + "(" + strings.Join(def.Interfaces, ",") + ")\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Blueprint returns blueprint of the container definitions. Definitions of ancestors included.
+func (c *Container) Blueprint() Blueprint {
+	b := Blueprint{
+		Version: BlueprintVersion,
+	}
+	for _, n := range c.schema.all() {
+		// interfaces and implicit types are not part of declarative wiring
+		if n.origin != nil || n.implicit {
+			continue
+		}
+		def := BlueprintDefinition{
+			Type:       n.rt.String(),
+			Provenance: n.frame.function,
+		}
+		if len(n.tags) > 0 && !n.sensitive {
+			def.Tags = n.tags
+		}
+		def.Sensitive = n.sensitive
+		for _, i := range n.interfaces {
+			def.Interfaces = append(def.Interfaces, i.String())
+		}
+		sort.Strings(def.Interfaces)
+		b.Definitions = append(b.Definitions, def)
+	}
+	sort.SliceStable(b.Definitions, func(i, j int) bool {
+		left := b.Definitions[i].Type + b.Definitions[i].Tags.String()
+		right := b.Definitions[j].Type + b.Definitions[j].Tags.String()
+		if left == right {
+			return strings.Join(b.Definitions[i].Interfaces, ",") < strings.Join(b.Definitions[j].Interfaces, ",")
+		}
+		return left < right
+	})
+	return b
+}
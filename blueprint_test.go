@@ -0,0 +1,73 @@
+package di_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestContainer_Blueprint(t *testing.T) {
+	t.Run("blueprint contains definitions", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"type": "public"}),
+			di.Provide(func() *http.ServeMux { return &http.ServeMux{} }, di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		b := c.Blueprint()
+		require.Equal(t, di.BlueprintVersion, b.Version)
+		require.Len(t, b.Definitions, 4)
+		require.Equal(t, "*di.Container", b.Definitions[0].Type)
+		require.Equal(t, "*http.ServeMux", b.Definitions[1].Type)
+		require.Equal(t, []string{"http.Handler"}, b.Definitions[1].Interfaces)
+		require.Equal(t, "*http.Server", b.Definitions[2].Type)
+		require.Equal(t, di.Tags{"type": "public"}, b.Definitions[2].Tags)
+		require.Equal(t, "github.com/goava/di_test.TestContainer_Blueprint.func1", b.Definitions[2].Provenance)
+		require.Equal(t, "di.BuildInfo", b.Definitions[3].Type)
+	})
+
+	t.Run("hash does not depend on provide order", func(t *testing.T) {
+		first, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }),
+			di.Provide(func() *http.ServeMux { return &http.ServeMux{} }),
+		)
+		require.NoError(t, err)
+		second, err := di.New()
+		require.NoError(t, err)
+		require.NoError(t, second.Provide(func() *http.ServeMux { return &http.ServeMux{} }))
+		require.NoError(t, second.Provide(func() *http.Server { return &http.Server{} }))
+		require.Equal(t, first.Blueprint().Hash(), second.Blueprint().Hash())
+		require.NoError(t, second.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"type": "public"}))
+		require.NotEqual(t, first.Blueprint().Hash(), second.Blueprint().Hash())
+	})
+
+	t.Run("blueprint serializable", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"type": "public"}),
+		)
+		require.NoError(t, err)
+		data, err := json.Marshal(c.Blueprint())
+		require.NoError(t, err)
+		var b di.Blueprint
+		require.NoError(t, json.Unmarshal(data, &b))
+		require.Equal(t, c.Blueprint().Hash(), b.Hash())
+	})
+
+	t.Run("tags of sensitive definition redacted", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"key": "secret"}, di.Sensitive()),
+		)
+		require.NoError(t, err)
+		b := c.Blueprint()
+		require.Equal(t, "*http.Server", b.Definitions[1].Type)
+		require.True(t, b.Definitions[1].Sensitive)
+		require.Nil(t, b.Definitions[1].Tags)
+		data, err := json.Marshal(b)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "secret")
+		require.Contains(t, string(data), `"sensitive":true`)
+	})
+}
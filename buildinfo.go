@@ -0,0 +1,93 @@
+package di
+
+import (
+	"runtime/debug"
+	"sort"
+	"time"
+)
+
+// BuildInfo is a build information of the binary. Container provides it by default.
+//
+//	func NewVersionHandler(info di.BuildInfo) *VersionHandler {
+//		return &VersionHandler{version: info.Version}
+//	}
+type BuildInfo struct {
+	// Path is a main package path.
+	Path string
+	// Version is a main module version.
+	Version string
+	// Commit is a version control revision.
+	Commit string
+	// Time is a version control commit time.
+	Time time.Time
+	// Modified is true if source tree had local modifications.
+	Modified bool
+	// GoVersion is a version of Go toolchain that built the binary.
+	GoVersion string
+}
+
+// readBuildInfo reads build information embedded into binary.
+func readBuildInfo() BuildInfo {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return BuildInfo{}
+	}
+	info := BuildInfo{
+		Path:      bi.Path,
+		Version:   bi.Main.Version,
+		GoVersion: bi.GoVersion,
+	}
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Commit = setting.Value
+		case "vcs.time":
+			info.Time, _ = time.Parse(time.RFC3339, setting.Value)
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	return info
+}
+
+// LinkerVars returns container option that provides string variables populated by
+// -ldflags "-X" as named string definitions. The variables read on resolve. Empty "version"
+// and "commit" variables fall back to BuildInfo values.
+//
+//	// go build -ldflags "-X main.version=v1.2.0 -X main.commit=4f2a1c3"
+//	var version, commit string
+//
+//	container, err := di.New(
+//		di.LinkerVars(map[string]*string{"version": &version, "commit": &commit}),
+//	)
+//	var v string
+//	err = container.Resolve(&v, di.Name("version"))
+func LinkerVars(vars map[string]*string) Option {
+	frame := stacktrace(0)
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return option(func(c *diopts) {
+		for _, name := range names {
+			name, v := name, vars[name]
+			c.provides = append(c.provides, provideOptions{
+				frame,
+				func(info BuildInfo) string {
+					if *v != "" {
+						return *v
+					}
+					switch name {
+					case "version":
+						return info.Version
+					case "commit":
+						return info.Commit
+					}
+					return ""
+				},
+				[]ProvideOption{Tags{"name": name}},
+			})
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package di
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Clock is a source of current time. Depend on it instead of time.Now() to make time
+// replaceable in tests. See di.StdClock() and ditest.ProvideClock().
+type Clock interface {
+	// Now returns current time.
+	Now() time.Time
+	// Since returns time elapsed since t.
+	Since(t time.Time) time.Duration
+}
+
+// Rand is a source of pseudo-random numbers. It safe for concurrent use.
+// See di.StdRand() and ditest.ProvideRand().
+type Rand interface {
+	// Int63 returns a non-negative pseudo-random 63-bit integer.
+	Int63() int64
+	// Intn returns a non-negative pseudo-random number in [0,n).
+	Intn(n int) int
+	// Float64 returns a pseudo-random number in [0.0,1.0).
+	Float64() float64
+}
+
+// StdClock returns container option that provides di.Clock based on system time.
+func StdClock() Option {
+	return Provide(func() Clock { return stdClock{} })
+}
+
+// StdRand returns container option that provides di.Rand based on math/rand.
+func StdRand() Option {
+	return Provide(func() Rand { return stdRand{} })
+}
+
+// stdClock is a system time clock.
+type stdClock struct {
+}
+
+func (stdClock) Now() time.Time                  { return time.Now() }
+func (stdClock) Since(t time.Time) time.Duration { return time.Since(t) }
+
+// stdRand uses top-level math/rand functions that safe for concurrent use.
+type stdRand struct {
+}
+
+func (stdRand) Int63() int64     { return rand.Int63() }
+func (stdRand) Intn(n int) int   { return rand.Intn(n) }
+func (stdRand) Float64() float64 { return rand.Float64() }
@@ -0,0 +1,30 @@
+package di_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestStdClock(t *testing.T) {
+	c, err := di.New(di.StdClock())
+	require.NoError(t, err)
+	var clock di.Clock
+	require.NoError(t, c.Resolve(&clock))
+	now := clock.Now()
+	require.WithinDuration(t, time.Now(), now, time.Second)
+	require.True(t, clock.Since(now) >= 0)
+}
+
+func TestStdRand(t *testing.T) {
+	c, err := di.New(di.StdRand())
+	require.NoError(t, err)
+	var r di.Rand
+	require.NoError(t, c.Resolve(&r))
+	require.True(t, r.Intn(10) < 10)
+	require.True(t, r.Int63() >= 0)
+	require.True(t, r.Float64() < 1)
+}
@@ -0,0 +1,44 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// factoryCompiler compiles nodes of factories.
+type factoryCompiler struct {
+	factory Factory
+	// node is a node of factory, consumer of resolved dependencies
+	node *node
+	// building is true while factory creates instance
+	building bool
+}
+
+func (c *factoryCompiler) deps(s schema) ([]*node, error) {
+	return nil, nil
+}
+
+func (c *factoryCompiler) compile(dependencies []reflect.Value, s schema) (reflect.Value, error) {
+	rt := c.factory.Type()
+	// dependencies of factory are not declared, so cycle can be detected only on build
+	if c.building {
+		return reflect.Value{}, fmt.Errorf("%w: factory of %s depends on itself", ErrCycleDetected, rt)
+	}
+	c.building = true
+	defer func() { c.building = false }()
+	v, cleanup, err := c.factory.New(schemaResolver{s: s.scope(), consumer: c.node})
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	s.cleanup(cleanup)
+	if v == nil {
+		return reflect.Zero(rt), nil
+	}
+	rv := reflect.ValueOf(v)
+	if !rv.Type().AssignableTo(rt) {
+		return reflect.Value{}, fmt.Errorf("factory returned %s that not assignable to %s", rv.Type(), rt)
+	}
+	result := reflect.New(rt).Elem()
+	result.Set(rv)
+	return result, nil
+}
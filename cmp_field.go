@@ -0,0 +1,26 @@
+package di
+
+import (
+	"reflect"
+)
+
+// fieldCompiler compiles field of struct node.
+type fieldCompiler struct {
+	source *node
+	index  int
+}
+
+func (c fieldCompiler) deps(s schema) ([]*node, error) {
+	return []*node{c.source}, nil
+}
+
+func (c fieldCompiler) compile(dependencies []reflect.Value, s schema) (reflect.Value, error) {
+	rv := dependencies[0]
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Zero(rv.Type().Elem().Field(c.index).Type), nil
+		}
+		rv = rv.Elem()
+	}
+	return rv.Field(c.index), nil
+}
@@ -7,6 +7,8 @@ import (
 type groupCompiler struct {
 	rt      reflect.Type
 	matched []*node
+	// names is a keys of matched nodes in named group
+	names []string
 }
 
 // newGroupCompiler creates group compiler of rt and with matched nodes.
@@ -17,10 +19,42 @@ func newGroupCompiler(rt reflect.Type, matched []*node) *groupCompiler {
 	}
 }
 
+// newNamedGroupCompiler creates compiler of map rt with matched nodes keyed by names.
+func newNamedGroupCompiler(rt reflect.Type, matched []*node, names []string) *groupCompiler {
+	return &groupCompiler{
+		rt:      rt,
+		matched: matched,
+		names:   names,
+	}
+}
+
+// isNamedGroup checks that t is a named group type map[string]T.
+func isNamedGroup(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
 func (c *groupCompiler) deps(s schema) (deps []*node, err error) {
 	return c.matched, nil
 }
 
+func (c *groupCompiler) members() []*node {
+	return c.matched
+}
+
+// memberCompiler is a compiler of node which dependencies are members of group, named group
+// or keyed registry.
+type memberCompiler interface {
+	// members returns member nodes.
+	members() []*node
+}
+
 func (c *groupCompiler) compile(dependencies []reflect.Value, s schema) (reflect.Value, error) {
+	if c.names != nil {
+		rv := reflect.MakeMapWithSize(c.rt, len(dependencies))
+		for i, dep := range dependencies {
+			rv.SetMapIndex(reflect.ValueOf(c.names[i]).Convert(c.rt.Key()), dep)
+		}
+		return rv, nil
+	}
 	return reflect.Append(reflect.New(c.rt).Elem(), dependencies...), nil
 }
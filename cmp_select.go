@@ -0,0 +1,61 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// selectCompiler compiles node which implementation selected by key on build.
+type selectCompiler struct {
+	rt       reflect.Type
+	selector function
+	// candidates is a candidate implementations by key
+	candidates map[string]*node
+}
+
+func (c *selectCompiler) deps(s schema) (deps []*node, err error) {
+	for i := 0; i < c.selector.NumIn(); i++ {
+		node, err := s.find(c.selector.In(i), Tags{})
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, node)
+	}
+	return deps, nil
+}
+
+func (c *selectCompiler) compile(dependencies []reflect.Value, s schema) (reflect.Value, error) {
+	res := funcResult(c.selector.Call(dependencies))
+	if len(res) == 2 {
+		if err := res.error(1); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	key := res.value().String()
+	candidate, ok := c.candidates[key]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("unknown selection %q, expected one of %s", key, strings.Join(c.keys(), ", "))
+	}
+	if candidate.owner == nil {
+		candidate.owner = s.scope()
+	}
+	v, err := candidate.Value(s)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%s %q: %w", candidate, key, err)
+	}
+	result := reflect.New(c.rt).Elem()
+	result.Set(v)
+	return result, nil
+}
+
+// keys returns sorted keys of candidates.
+func (c *selectCompiler) keys() []string {
+	keys := make([]string, 0, len(c.candidates))
+	for key := range c.candidates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,11 +1,14 @@
 package di
 
 import (
+	"fmt"
 	"reflect"
 )
 
 type valueCompiler struct {
 	rv reflect.Value
+	// copy makes copy of value for each consumer, see di.Copy()
+	copy CopyFunc
 }
 
 func (v valueCompiler) deps(s schema) ([]*node, error) {
@@ -13,5 +16,12 @@ func (v valueCompiler) deps(s schema) ([]*node, error) {
 }
 
 func (v valueCompiler) compile(dependencies []reflect.Value, s schema) (reflect.Value, error) {
-	return v.rv, nil
+	if v.copy == nil {
+		return v.rv, nil
+	}
+	copied := reflect.ValueOf(v.copy(v.rv.Interface()))
+	if !copied.IsValid() || copied.Type() != v.rv.Type() {
+		return reflect.Value{}, fmt.Errorf("copy of %s has different type", v.rv.Type())
+	}
+	return copied, nil
 }
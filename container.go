@@ -1,9 +1,13 @@
 package di
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 )
 
 // Container is a dependency injection container.
@@ -12,6 +16,24 @@ type Container struct {
 	schema *defaultSchema
 	// Array of provider cleanups.
 	cleanups []func()
+	// Group change subscribers by group element type.
+	groupSubscribers map[reflect.Type][]GroupChangeFunc
+	// duplicates is a rule of duplicate definitions detection
+	duplicates DuplicateRule
+	// exprConfig is a config with variables of provide conditions
+	exprConfig Pointer
+	// exprEvaluator is an evaluator of provide conditions
+	exprEvaluator ExprEvaluator
+	// eager is true if all singletons constructed on provide
+	eager bool
+	// pending is a nodes that wait eager initialization
+	pending []*node
+	// strict is true if provide options without effect cause error
+	strict bool
+	// scopeCtx is a context of scope created by Container.Scope()
+	scopeCtx context.Context
+	// modules is a module factories of di.Include() by name
+	modules map[string]ModuleFactory
 }
 
 // New constructs container with provided options. Example usage (simplified):
@@ -43,9 +65,27 @@ type Container struct {
 //		// handle error
 //	}
 func New(options ...Option) (_ *Container, err error) {
+	return NewContext(context.Background(), options...)
+}
+
+// NewContext constructs container with provided options like New. Constructors and invocations
+// called on container creation respect ctx cancellation and deadline. The ctx is not used
+// after container creation.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	container, err := di.NewContext(ctx,
+//		di.Provide(NewHTTPServer),
+//		di.Invoke(StartServer),
+//	)
+//	if errors.Is(err, context.DeadlineExceeded) {
+//		// handle startup timeout
+//	}
+func NewContext(ctx context.Context, options ...Option) (_ *Container, err error) {
 	c := &Container{
-		schema:   newDefaultSchema(),
-		cleanups: []func(){},
+		schema:           newDefaultSchema(),
+		cleanups:         []func(){},
+		groupSubscribers: map[reflect.Type][]GroupChangeFunc{},
 	}
 	var di diopts
 	// apply container diopts
@@ -53,9 +93,15 @@ func New(options ...Option) (_ *Container, err error) {
 		opt.apply(&di)
 	}
 	// provide container to advanced usage e.g. condition providing
-	_ = c.provide(func() *Container { return c })
+	_ = c.provide(callerFrame{}, func() *Container { return c })
+	// provide build information of the binary
+	_ = c.provide(callerFrame{}, readBuildInfo)
+	c.schema.ctx = ctx
+	defer func() {
+		c.schema.ctx = context.Background()
+	}()
 	if err := c.apply(di); err != nil {
-		return nil, err
+		return nil, renderError(err)
 	}
 	return c, nil
 }
@@ -73,14 +119,36 @@ func (c *Container) Apply(options ...Option) error {
 	for _, opt := range options {
 		opt.apply(&di)
 	}
-	return c.apply(di)
+	return renderError(c.apply(di))
+}
+
+// ApplyNamespaced applies options to container with names qualified by prefix. The name of
+// provided or resolved type becomes "prefix.name" and types without name get name "prefix".
+// It allows to apply the same options twice without name collisions.
+//
+//	err := container.ApplyNamespaced("orders", kafkaModule)
+//	if err != nil {
+//		// handle error
+//	}
+//	var client *kafka.Client
+//	err = container.Resolve(&client, di.Tags{"name": "orders"})
+func (c *Container) ApplyNamespaced(prefix string, options ...Option) error {
+	var di diopts
+	for _, opt := range options {
+		opt.apply(&di)
+	}
+	di.namespace(prefix)
+	return renderError(c.apply(di))
 }
 
 // Provide provides to container reliable way to build type. The constructor will be invoked lazily on-demand.
 // For more information about constructors see Constructor interface. ProvideOption can add additional behavior to
 // the process of type resolving.
 func (c *Container) Provide(constructor Constructor, options ...ProvideOption) error {
-	if err := c.provide(constructor, options...); err != nil {
+	if err := c.provide(stacktrace(0), constructor, options...); err != nil {
+		return errWithStack(err)
+	}
+	if err := c.initEager(); err != nil {
 		return errWithStack(err)
 	}
 	return nil
@@ -88,7 +156,10 @@ func (c *Container) Provide(constructor Constructor, options ...ProvideOption) e
 
 // ProvideValue provides value as is.
 func (c *Container) ProvideValue(value Value, options ...ProvideOption) error {
-	if err := c.provideValue(value, options...); err != nil {
+	if err := c.provideValue(stacktrace(0), value, options...); err != nil {
+		return errWithStack(err)
+	}
+	if err := c.initEager(); err != nil {
 		return errWithStack(err)
 	}
 	return nil
@@ -118,6 +189,25 @@ func (c *Container) Invoke(invocation Invocation, options ...InvokeOption) error
 	return nil
 }
 
+// InvokeMethod resolves receiver and calls its method by name. Method parameters resolved
+// from the container like invocation arguments. Method expressions like (*Service).Start can be
+// passed to Invoke() as is.
+//
+//	var service *LegacyService
+//	if err := container.InvokeMethod(&service, "Start"); err != nil {
+//		// handle error
+//	}
+func (c *Container) InvokeMethod(receiver Pointer, method string, options ...ResolveOption) error {
+	err := c.invokeMethod(receiver, method, options...)
+	if err != nil && knownError(err) {
+		return errWithStack(err)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 type Pointer interface{}
 
 // Has checks that type exists in container, if not it return false.
@@ -129,7 +219,8 @@ type Pointer interface{}
 //
 // It like Resolve() but doesn't instantiate a type.
 func (c *Container) Has(target Pointer, options ...ResolveOption) (bool, error) {
-	if _, err := c.find(target, options...); errors.Is(err, ErrTypeNotExists) {
+	var dependency *dependencyError
+	if _, err := c.find(target, options...); errors.Is(err, ErrTypeNotExists) && !errors.As(err, &dependency) {
 		return false, nil
 	} else if err != nil {
 		return false, err
@@ -137,6 +228,81 @@ func (c *Container) Has(target Pointer, options ...ResolveOption) (bool, error)
 	return true, nil
 }
 
+// Missing is a target of Container.HasAll() or Container.HasAny() that not exists in container.
+type Missing struct {
+	// Type is a type of target, nil if target is not a pointer.
+	Type reflect.Type
+	// Err is a reason why target not exists.
+	Err error
+}
+
+// HasAll checks that all targets exist in container. Missing targets returned in order of
+// arguments.
+//
+//	var tracer *Tracer
+//	var exporter *Exporter
+//	if ok, missing := container.HasAll(&tracer, &exporter); !ok {
+//		log.Printf("tracing disabled: %v", missing)
+//	}
+func (c *Container) HasAll(targets ...Pointer) (bool, []Missing) {
+	missing := c.missing(targets)
+	return len(missing) == 0, missing
+}
+
+// HasAny checks that at least one of targets exists in container. Missing targets returned in
+// order of arguments.
+func (c *Container) HasAny(targets ...Pointer) (bool, []Missing) {
+	missing := c.missing(targets)
+	return len(missing) < len(targets), missing
+}
+
+// missing returns targets that not exist in container.
+func (c *Container) missing(targets []Pointer) (missing []Missing) {
+	for _, target := range targets {
+		_, err := c.find(target)
+		if err == nil {
+			continue
+		}
+		m := Missing{Err: err}
+		if target != nil && reflect.TypeOf(target).Kind() == reflect.Ptr {
+			m.Type = reflect.TypeOf(target).Elem()
+		}
+		missing = append(missing, m)
+	}
+	return missing
+}
+
+// NumDefinitions returns count of definitions registered in the container and its ancestors.
+// Interfaces registered with di.As() counted as separate definitions.
+func (c *Container) NumDefinitions() int {
+	return len(c.schema.all())
+}
+
+// Types returns sorted list of types that can be resolved from the container without
+// building them.
+//
+//	require.Contains(t, container.Types(), reflect.TypeOf(new(http.Server)))
+func (c *Container) Types() []reflect.Type {
+	return c.Tagged(nil)
+}
+
+// Tagged returns sorted list of types that have at least one definition matching tags.
+func (c *Container) Tagged(tags Tags) []reflect.Type {
+	var types []reflect.Type
+	seen := map[reflect.Type]bool{}
+	for _, n := range c.schema.all() {
+		if seen[n.rt] || !n.tags.match(tags) {
+			continue
+		}
+		seen[n.rt] = true
+		types = append(types, n.rt)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+	return types
+}
+
 // Resolve resolves type and fills target pointer.
 //
 //	var server *http.Server
@@ -150,13 +316,81 @@ func (c *Container) Resolve(ptr Pointer, options ...ResolveOption) error {
 	return nil
 }
 
+// ResolveAll resolves types of all targets. Dependency graphs of all targets checked before
+// construction, if some of them can't be resolved the error is a *VerificationError with problem
+// of each target and nothing constructed. Targets assigned only when all of them built.
+//
+//	var server *http.Server
+//	var logger *log.Logger
+//	if err := container.ResolveAll(&server, &logger); err != nil {
+//		// handle error
+//	}
+func (c *Container) ResolveAll(targets ...Pointer) error {
+	if err := c.resolveAll(targets); err != nil {
+		return errWithStack(err)
+	}
+	return nil
+}
+
+func (c *Container) resolveAll(targets []Pointer) error {
+	nodes := make([]*node, 0, len(targets))
+	var problems []error
+	for _, target := range targets {
+		node, err := c.find(target)
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	if len(problems) > 0 {
+		return &VerificationError{Problems: problems}
+	}
+	values := make([]reflect.Value, 0, len(nodes))
+	for _, node := range nodes {
+		start := time.Now()
+		value, err := node.Value(c.schema)
+		c.schema.record(HistoryResolve, node, start, err)
+		if err != nil {
+			return fmt.Errorf("%s: %w", node, err)
+		}
+		values = append(values, value)
+	}
+	for i, target := range targets {
+		c.assign(target, values[i])
+	}
+	return nil
+}
+
+// ResolveNamedType resolves type by its string representation as it presented in errors,
+// e.g. "*http.Server" or "*http.Server[name:public]". It useful for debug tools and admin
+// endpoints that don't know types at compile time.
+//
+//	server, err := container.ResolveNamedType("*http.Server")
+//	if err != nil {
+//		// handle error
+//	}
+func (c *Container) ResolveNamedType(name string, options ...ResolveOption) (interface{}, error) {
+	rt, tags, err := c.lookupNamedType(name)
+	if err != nil {
+		return nil, errWithStack(err)
+	}
+	ptr := reflect.New(rt)
+	if err := c.resolve(ptr.Interface(), append([]ResolveOption{tags}, options...)...); err != nil {
+		return nil, errWithStack(err)
+	}
+	return ptr.Elem().Interface(), nil
+}
+
 // ValueFunc is a lazy-loading wrapper for iteration.
 type ValueFunc func() (interface{}, error)
 
 // IterateFunc function that will be called on each instance in iterate selection.
 type IterateFunc func(tags Tags, value ValueFunc) error
 
-// Iterate iterates over group of Pointer type with IterateFunc.
+// Iterate iterates over group of Pointer type with IterateFunc. If Pointer type is not a
+// slice, it iterates over all definitions assignable to the type, e.g. over all definitions
+// that implement interface. Options like di.Tags narrow definitions.
 //
 //  var servers []*http.Server
 //  iterFn := func(tags di.Tags, loader ValueFunc) error {
@@ -169,6 +403,9 @@ type IterateFunc func(tags Tags, value ValueFunc) error
 //  }
 //  container.Iterate(&servers, iterFn)
 func (c *Container) Iterate(target Pointer, fn IterateFunc, options ...ResolveOption) error {
+	if rt := reflect.TypeOf(target); rt != nil && rt.Kind() == reflect.Ptr && rt.Elem().Kind() != reflect.Slice {
+		return c.sweep(rt.Elem(), fn, options...)
+	}
 	node, err := c.find(target, options...)
 	if err != nil {
 		return err
@@ -192,6 +429,76 @@ func (c *Container) Iterate(target Pointer, fn IterateFunc, options ...ResolveOp
 	return fmt.Errorf("iteration can be used with groups only")
 }
 
+// sweep iterates over all definitions assignable to type. Interface definitions skipped
+// because its implementations are iterated.
+func (c *Container) sweep(t reflect.Type, fn IterateFunc, options ...ResolveOption) error {
+	params := ResolveParams{}
+	for _, opt := range options {
+		opt.applyResolve(&params)
+	}
+	if params.err != nil {
+		return params.err
+	}
+	var nodes []*node
+	for _, n := range c.schema.all() {
+		if n.implicit || n.origin != nil || !n.rt.AssignableTo(t) {
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+	nodes = query{tags: params.Tags, selector: params.Selector, filter: params.Filter}.match(nodes)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].String() == nodes[j].String() {
+			return fmt.Sprint(nodes[i].frame) < fmt.Sprint(nodes[j].frame)
+		}
+		return nodes[i].String() < nodes[j].String()
+	})
+	for _, n := range nodes {
+		n := n
+		err := fn(n.tags, func() (interface{}, error) {
+			if err := c.schema.prepare(n); err != nil {
+				return nil, err
+			}
+			v, err := n.Value(c.schema)
+			if err != nil {
+				return nil, err
+			}
+			return v.Interface(), nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s failed: %s", n, err)
+		}
+	}
+	return nil
+}
+
+// GroupChangeFunc is a function that will be called when new member added to the group.
+// The tags are tags of added member.
+type GroupChangeFunc func(tags Tags)
+
+// OnGroupChange subscribes fn on changes of group of target type. Groups are computed on each
+// resolve, so a group resolved after change contains the new member. Subscribers can use it
+// to pick up members that provided after group resolution, e.g. late-registered plugins.
+//
+//	err := container.OnGroupChange(new([]Plugin), func(tags di.Tags) {
+//		var plugin Plugin
+//		if err := container.Resolve(&plugin, tags); err != nil {
+//			// handle error
+//		}
+//	})
+func (c *Container) OnGroupChange(target Pointer, fn GroupChangeFunc) error {
+	if target == nil {
+		return errWithStack(fmt.Errorf("target must be a pointer to slice, got nil"))
+	}
+	rt := reflect.TypeOf(target)
+	if rt.Kind() != reflect.Ptr || rt.Elem().Kind() != reflect.Slice {
+		return errWithStack(fmt.Errorf("target must be a pointer to slice, got %s", rt))
+	}
+	elem := rt.Elem().Elem()
+	c.groupSubscribers[elem] = append(c.groupSubscribers[elem], fn)
+	return nil
+}
+
 // Cleanup runs destructors in reverse order that was been created.
 func (c *Container) Cleanup() {
 	for i := len(c.schema.cleanups) - 1; i >= 0; i-- {
@@ -205,21 +512,87 @@ func (c *Container) AddParent(parent *Container) error {
 	return c.schema.addParent(parent.schema)
 }
 
+// NewChild creates child container. The child looks up definitions in itself first and falls
+// back to the container when type not found. It useful to register request or job specific
+// values without affecting the container. The child cleanups run independently by its Cleanup().
+//
+//	child, err := container.NewChild(
+//		di.ProvideValue(request),
+//	)
+//	if err != nil {
+//		// handle error
+//	}
+//	defer child.Cleanup()
+func (c *Container) NewChild(options ...Option) (*Container, error) {
+	child := &Container{
+		schema:           newDefaultSchema(),
+		cleanups:         []func(){},
+		groupSubscribers: map[reflect.Type][]GroupChangeFunc{},
+		duplicates:       c.duplicates,
+		exprConfig:       c.exprConfig,
+		exprEvaluator:    c.exprEvaluator,
+		eager:            c.eager,
+		strict:           c.strict,
+		modules:          map[string]ModuleFactory{},
+	}
+	for name, factory := range c.modules {
+		child.modules[name] = factory
+	}
+	child.schema.fallback = true
+	child.schema.authorizers = append([]AuthorizeFunc(nil), c.schema.authorizers...)
+	child.schema.middlewares = append([]Middleware(nil), c.schema.middlewares...)
+	child.schema.tagName = c.schema.tagName
+	child.schema.history = c.schema.history
+	child.schema.cycles = c.schema.cycles
+	child.schema.profiles = append([]string(nil), c.schema.profiles...)
+	child.schema.shadowHooks = append(child.schema.shadowHooks, c.schema.shadowHooks...)
+	if err := child.schema.addParent(c.schema); err != nil {
+		return nil, errWithStack(err)
+	}
+	// provide child to make it resolvable from itself
+	_ = child.provide(callerFrame{}, func() *Container { return child })
+	var di diopts
+	for _, opt := range options {
+		opt.apply(&di)
+	}
+	if err := child.apply(di); err != nil {
+		return nil, renderError(err)
+	}
+	return child, nil
+}
+
 func (c *Container) apply(di diopts) error {
+	for _, setting := range di.settings {
+		setting(c)
+	}
+	for _, include := range di.includes {
+		if err := c.include(include.source, include.namespaces); err != nil {
+			return fmt.Errorf("%s: %w", include.frame, err)
+		}
+	}
 	for _, provide := range di.values {
-		if err := c.provideValue(provide.value, provide.options...); err != nil {
+		if err := c.provideValue(provide.frame, provide.value, provide.options...); err != nil {
 			return fmt.Errorf("%s: %w", provide.frame, err)
 		}
 	}
 	// process di.Resolve() diopts
 	for _, provide := range di.provides {
-		if err := c.provide(provide.constructor, provide.options...); err != nil {
+		if err := c.provide(provide.frame, provide.constructor, provide.options...); err != nil {
 			return fmt.Errorf("%s: %w", provide.frame, err)
 		}
 	}
+	if c.strict {
+		c.checkSharedMutable()
+	}
+	if err := c.initEager(); err != nil {
+		return err
+	}
 	// error omitted because if logger could not be resolved it will be default
 	// process di.Invoke() diopts
 	for _, invoke := range di.invokes {
+		if err := c.schema.ctx.Err(); err != nil {
+			return fmt.Errorf("%s: %w", invoke.frame, err)
+		}
 		err := c.invoke(invoke.fn, invoke.options...)
 		if err != nil && knownError(err) {
 			return fmt.Errorf("%s: %w", invoke.frame, err)
@@ -237,27 +610,77 @@ func (c *Container) apply(di diopts) error {
 	return nil
 }
 
-func (c *Container) provide(constructor Constructor, options ...ProvideOption) error {
+func (c *Container) provide(frame callerFrame, constructor Constructor, options ...ProvideOption) error {
 	if constructor == nil {
-		return fmt.Errorf("invalid constructor signature, got nil")
+		return fmt.Errorf("%w, got nil", ErrInvalidConstructor)
 	}
 	params := ProvideParams{}
 	// apply provide options
 	for _, opt := range options {
 		opt.applyProvide(&params)
 	}
-	n, err := newConstructorNode(constructor)
+	if ok, err := c.satisfies(params); err != nil || !ok {
+		return err
+	}
+	var n *node
+	var err error
+	switch ctor := constructor.(type) {
+	case Factory:
+		n, err = newFactoryNode(ctor)
+	case *selection:
+		n, err = newSelectNode(ctor)
+	case *structure:
+		n, err = newStructNode(ctor)
+	case *parameterized:
+		n, err = newParameterizedNode(ctor)
+	case *shadow:
+		n, err = newShadowNode(ctor)
+	case *literal:
+		n, err = newLiteralNode(ctor)
+	default:
+		n, err = newConstructorNode(constructor)
+	}
 	if err != nil {
 		return err
 	}
+	n.frame = frame
+	n.namespace = params.namespace
 	n.decorators = params.Decorators
+	n.cacheError = params.CacheError
+	n.lifetime = params.Lifetime
+	n.cache = params.Cache
+	if n.lifetime == Cached && n.cache == nil {
+		return fmt.Errorf("%s: cached lifetime requires cache, use di.WithCache()", n)
+	}
+	n.sensitive = params.Sensitive
+	n.order = params.Order
+	n.sharedMutable = params.SharedMutable
+	n.byDefault = params.Default
+	n.primary = params.Primary
+	n.implemented = params.Implemented
+	if n.setters, err = inspectSetters(n.rt, params); err != nil {
+		return err
+	}
+	if err := c.checkOptions(n, params); err != nil {
+		return err
+	}
+	checkInheritedTags(c.schema, n, params.Tags)
 	for k, v := range params.Tags {
 		n.tags[k] = v
 	}
-	return c.provideNode(n, params)
+	if err := n.tags.validate(); err != nil {
+		return err
+	}
+	if err := c.provideNode(n, params); err != nil {
+		return err
+	}
+	if (params.Eager || c.eager) && n.lifetime == Singleton {
+		c.pending = append(c.pending, n)
+	}
+	return nil
 }
 
-func (c *Container) provideValue(value Value, options ...ProvideOption) error {
+func (c *Container) provideValue(frame callerFrame, value Value, options ...ProvideOption) error {
 	if value == nil {
 		return fmt.Errorf("invalid value, got nil")
 	}
@@ -266,21 +689,54 @@ func (c *Container) provideValue(value Value, options ...ProvideOption) error {
 	for _, opt := range options {
 		opt.applyProvide(&params)
 	}
+	if ok, err := c.satisfies(params); err != nil || !ok {
+		return err
+	}
 	v := reflect.ValueOf(value)
 	n := &node{
 		compiler: valueCompiler{
-			rv: v,
+			rv:   v,
+			copy: params.Copy,
 		},
-		rv:         new(reflect.Value),
-		rt:         v.Type(),
-		tags:       params.Tags,
-		decorators: params.Decorators,
+		rv:            new(reflect.Value),
+		rt:            v.Type(),
+		tags:          params.Tags,
+		frame:         frame,
+		decorators:    params.Decorators,
+		sensitive:     params.Sensitive,
+		order:         params.Order,
+		sharedMutable: params.SharedMutable,
+		byDefault:     params.Default,
+		primary:       params.Primary,
+		implemented:   params.Implemented,
+	}
+	// each consumer receives own copy
+	if params.Copy != nil {
+		n.lifetime = Transient
+	}
+	if err := c.checkOptions(n, params); err != nil {
+		return err
+	}
+	if err := n.tags.validate(); err != nil {
+		return err
 	}
-	return c.provideNode(n, params)
+	if err := c.provideNode(n, params); err != nil {
+		return err
+	}
+	if (params.Eager || c.eager) && n.lifetime == Singleton {
+		c.pending = append(c.pending, n)
+	}
+	return nil
 }
 
 func (c *Container) provideNode(n *node, params ProvideParams) error {
+	n.dependencies = declaredDependencies(n, c.schema.tagName)
+	if params.Override {
+		c.override(n)
+	}
 	c.schema.register(n)
+	c.schema.record(HistoryProvide, n, time.Now(), nil)
+	c.checkShadowing(n)
 	// register interfaces
 	for _, cur := range params.Interfaces {
 		i, err := inspectInterfacePointer(cur)
@@ -290,73 +746,168 @@ func (c *Container) provideNode(n *node, params ProvideParams) error {
 		if !n.rt.Implements(i.Type) {
 			return fmt.Errorf("%s not implement %s", n, i.Type)
 		}
-		c.schema.register(&node{
-			rv:         n.rv,
-			rt:         i.Type,
-			tags:       n.tags,
-			compiler:   n.compiler,
-			decorators: n.decorators,
-		})
+		n.interfaces = append(n.interfaces, i.Type)
+		c.schema.register(n.as(i.Type))
+	}
+	if n.implemented != nil {
+		c.schema.implementers = append(c.schema.implementers, n)
+	}
+	c.notifyGroupChange(n.rt, n.tags)
+	for _, i := range n.interfaces {
+		c.notifyGroupChange(i, n.tags)
+	}
+	if isOut(n.rt) {
+		c.provideOutFields(n)
+	}
+	if params.ExposeFields {
+		return c.exposeFields(n)
 	}
 	return nil
 }
 
+// notifyGroupChange calls subscribers of group with element type t.
+func (c *Container) notifyGroupChange(t reflect.Type, tags Tags) {
+	for _, fn := range c.groupSubscribers[t] {
+		fn(tags)
+	}
+}
+
 func (c *Container) resolve(ptr Pointer, options ...ResolveOption) error {
 	node, err := c.find(ptr, options...)
 	if err != nil {
 		return err
 	}
+	start := time.Now()
 	value, err := node.Value(c.schema)
+	c.schema.record(HistoryResolve, node, start, err)
 	if err != nil {
 		return fmt.Errorf("%s: %w", node, err)
 	}
+	c.assign(ptr, value)
+	return nil
+}
+
+// assign assigns value to target that ptr points to.
+func (c *Container) assign(ptr Pointer, value reflect.Value) {
 	rv := reflect.ValueOf(ptr)
 	target := rv.Elem()
 	if canInject(rv.Type()) {
-		for index := range parsePopulateFields(target.Type()) {
+		for index := range parsePopulateFields(target.Type(), c.schema.tagName) {
 			target.Field(index).Set(value.Field(index))
 		}
 	} else {
 		target.Set(value)
 	}
-	return nil
 }
 
-func (c *Container) invoke(invocation Invocation, _ ...InvokeOption) error {
-	// params := InvokeParams{}
-	// for _, opt := range diopts {
-	// 	opt.apply(&params)
-	// }
+func (c *Container) invoke(invocation Invocation, options ...InvokeOption) error {
+	params := InvokeParams{}
+	for _, opt := range options {
+		opt.apply(&params)
+	}
 	if invocation == nil {
-		return fmt.Errorf("%w, got %s", errInvalidInvocationSignature, "nil")
+		return fmt.Errorf("%w, got %s", ErrInvalidInvocation, "nil")
 	}
 	fn, valid := inspectFunction(invocation)
 	if !valid {
-		return fmt.Errorf("%w, got %s", errInvalidInvocationSignature, reflect.TypeOf(invocation))
+		return fmt.Errorf("%w, got %s", ErrInvalidInvocation, reflect.TypeOf(invocation))
 	}
 	if !validateInvocation(fn) {
-		return fmt.Errorf("%w, got %s", errInvalidInvocationSignature, reflect.TypeOf(invocation))
+		return fmt.Errorf("%w, got %s", ErrInvalidInvocation, reflect.TypeOf(invocation))
 	}
-	nodes, err := parseInvocationParameters(fn, c.schema)
+	args, err := c.invocationArgs(fn, params)
 	if err != nil {
 		return err
 	}
-	var args []reflect.Value
+	res := funcResult(fn.Call(args))
+	if len(res) == 0 {
+		return nil
+	}
+	return res.error(0)
+}
+
+func (c *Container) invokeMethod(receiver Pointer, method string, options ...ResolveOption) error {
+	if err := c.resolve(receiver, options...); err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(receiver).Elem()
+	mv := rv.MethodByName(method)
+	if !mv.IsValid() {
+		return fmt.Errorf("%w, %s has no method %s", ErrInvalidInvocation, rv.Type(), method)
+	}
+	return c.invoke(mv.Interface())
+}
+
+// invocationArgs resolves arguments of invocation function.
+func (c *Container) invocationArgs(fn function, params InvokeParams) ([]reflect.Value, error) {
+	lookup := schema(c.schema)
+	if params.namespace != "" {
+		lookup = namespacedSchema{
+			schema: c.schema,
+			prefix: params.namespace,
+		}
+	}
+	nodes, err := parseInvocationParameters(fn, lookup)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]reflect.Value, 0, len(nodes))
 	for _, node := range nodes {
 		if err := c.schema.prepare(node); err != nil {
-			return err
+			return nil, err
 		}
 		v, err := node.Value(c.schema)
 		if err != nil {
-			return fmt.Errorf("%s: %s", node, err)
+			return nil, fmt.Errorf("%s: %w", node, err)
 		}
 		args = append(args, v)
 	}
-	res := funcResult(fn.Call(args))
-	if len(res) == 0 {
-		return nil
+	return args, nil
+}
+
+// lookupNamedType finds type and tags by string representation of node.
+func (c *Container) lookupNamedType(name string) (reflect.Type, Tags, error) {
+	if strings.HasPrefix(name, "[]") {
+		rt, tags, err := c.lookupNamedType(name[2:])
+		if err != nil {
+			return nil, nil, err
+		}
+		return reflect.SliceOf(rt), tags, nil
+	}
+	var found reflect.Type
+	tags := Tags{}
+	for _, n := range c.schema.all() {
+		switch name {
+		case n.rt.String():
+		case n.String():
+			tags = n.tags
+		default:
+			continue
+		}
+		if found != nil && found != n.rt {
+			return nil, nil, fmt.Errorf("type name %s is ambiguous: %s and %s", name, fullTypeName(found), fullTypeName(n.rt))
+		}
+		found = n.rt
 	}
-	return res.error(0)
+	if found == nil {
+		return nil, nil, fmt.Errorf("type %s %w", name, ErrTypeNotExists)
+	}
+	return found, tags, nil
+}
+
+// fullTypeName returns string representation of type with full package path of its element
+// type, e.g. "*net/http.Server".
+func fullTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + fullTypeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + fullTypeName(t.Elem())
+	}
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
 }
 
 func (c *Container) find(ptr Pointer, options ...ResolveOption) (*node, error) {
@@ -371,7 +922,18 @@ func (c *Container) find(ptr Pointer, options ...ResolveOption) (*node, error) {
 	for _, opt := range options {
 		opt.applyResolve(&params)
 	}
-	node, err := c.schema.find(reflect.TypeOf(ptr).Elem(), params.Tags)
+	if params.err != nil {
+		return nil, params.err
+	}
+	if err := c.checkResolve(reflect.TypeOf(ptr).Elem(), params); err != nil {
+		return nil, err
+	}
+	node, err := c.schema.search(reflect.TypeOf(ptr).Elem(), query{
+		tags:     params.Tags,
+		selector: params.Selector,
+		exact:    params.Exact,
+		filter:   params.Filter,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -381,6 +943,62 @@ func (c *Container) find(ptr Pointer, options ...ResolveOption) (*node, error) {
 	return node, nil
 }
 
+// namespace qualifies names of provides and resolves with prefix. Dependencies of provided
+// types and invocations are looked up with qualified names first.
+func (d *diopts) namespace(prefix string) {
+	provide := provideOption(func(params *ProvideParams) {
+		if params.Tags == nil {
+			params.Tags = Tags{}
+		}
+		params.Tags["name"] = qualifiedName(prefix, params.Tags["name"])
+		params.namespace = prefix
+	})
+	invoke := invokeOption(func(params *InvokeParams) {
+		params.namespace = prefix
+	})
+	resolve := resolveOption(func(params *ResolveParams) {
+		if params.Tags == nil {
+			params.Tags = Tags{}
+		}
+		params.Tags["name"] = qualifiedName(prefix, params.Tags["name"])
+	})
+	// options copied to not modify slices passed by user
+	for i, p := range d.provides {
+		d.provides[i].options = append(append([]ProvideOption{}, p.options...), provide)
+	}
+	for i, v := range d.values {
+		d.values[i].options = append(append([]ProvideOption{}, v.options...), provide)
+	}
+	for i, inv := range d.invokes {
+		d.invokes[i].options = append(append([]InvokeOption{}, inv.options...), invoke)
+	}
+	for i, r := range d.resolves {
+		d.resolves[i].options = append(append([]ResolveOption{}, r.options...), resolve)
+	}
+	// included options qualified after materialization
+	for i, inc := range d.includes {
+		d.includes[i].namespaces = append(append([]string{}, inc.namespaces...), prefix)
+	}
+}
+
+// merge appends options of other to d.
+func (d *diopts) merge(other diopts) {
+	d.provides = append(d.provides, other.provides...)
+	d.values = append(d.values, other.values...)
+	d.invokes = append(d.invokes, other.invokes...)
+	d.resolves = append(d.resolves, other.resolves...)
+	d.settings = append(d.settings, other.settings...)
+	d.includes = append(d.includes, other.includes...)
+}
+
+// qualifiedName returns name qualified with prefix.
+func qualifiedName(prefix, name string) string {
+	if name == "" {
+		return prefix
+	}
+	return prefix + "." + name
+}
+
 type diopts struct {
 	// Array of di.Provide() options.
 	provides []provideOptions
@@ -390,4 +1008,8 @@ type diopts struct {
 	invokes []invokeOptions
 	// Array of di.Resolve() options.
 	resolves []resolveOptions
+	// Array of container settings, e.g. di.Duplicates().
+	settings []func(c *Container)
+	// Array of di.Include() options.
+	includes []includeOptions
 }
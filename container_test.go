@@ -1,13 +1,21 @@
 package di_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	htmltemplate "html/template"
 	"io"
+	"log"
 	"net"
 	"net/http"
 	"os"
+	"reflect"
+	"runtime"
+	"strings"
 	"testing"
+	texttemplate "text/template"
 
 	"github.com/stretchr/testify/require"
 
@@ -18,6 +26,45 @@ func init() {
 	di.SetTracer(di.StdTracer{})
 }
 
+func TestNewContext(t *testing.T) {
+	t.Run("cancelled context interrupts construction", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := di.NewContext(ctx,
+			di.Provide(func() *http.ServeMux {
+				cancel()
+				return &http.ServeMux{}
+			}),
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }),
+			di.Invoke(func(server *http.Server) {}),
+		)
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Contains(t, err.Error(), "construction of *http.Server interrupted: context canceled")
+	})
+
+	t.Run("cancelled context interrupts invocations", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var called bool
+		_, err := di.NewContext(ctx,
+			di.Invoke(func() { cancel() }),
+			di.Invoke(func() { called = true }),
+		)
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.False(t, called)
+	})
+
+	t.Run("context not used after creation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		c, err := di.NewContext(ctx,
+			di.Provide(func() *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		cancel()
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+	})
+}
+
 func TestContainer_Provide(t *testing.T) {
 	t.Run("simple constructor", func(t *testing.T) {
 		c, err := di.New()
@@ -206,6 +253,51 @@ func TestContainer_ProvideValue(t *testing.T) {
 	})
 }
 
+// registry is a test module registry.
+type registry []interface{}
+
+func (r registry) Constructors() []interface{} {
+	return r
+}
+
+func TestProvideFrom(t *testing.T) {
+	t.Run("all constructors provided", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideFrom(registry{
+				http.NewServeMux,
+				func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} },
+			}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.NotNil(t, server.Handler)
+	})
+
+	t.Run("provide options applied to each constructor", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideFrom(registry{
+				http.NewServeMux,
+				func() *http.Server { return &http.Server{} },
+			}, di.Tags{"module": "web"}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []reflect.Type{
+			reflect.TypeOf(new(http.ServeMux)),
+			reflect.TypeOf(new(http.Server)),
+		}, c.Tagged(di.Tags{"module": "web"}))
+	})
+
+	t.Run("invalid constructor", func(t *testing.T) {
+		_, err := di.New(
+			di.ProvideFrom(registry{1}),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.Contains(t, err.Error(), "invalid constructor signature, got int")
+	})
+}
+
 func TestContainer_Resolve(t *testing.T) {
 	t.Run("resolve into nil cause error", func(t *testing.T) {
 		c, err := di.New()
@@ -251,6 +343,42 @@ func TestContainer_Resolve(t *testing.T) {
 		require.Contains(t, err.Error(), ": *http.Server: server build failed")
 	})
 
+	t.Run("resolve retries failed build by default", func(t *testing.T) {
+		var calls int
+		c, err := di.New(
+			di.Provide(func() (*http.Server, error) {
+				calls++
+				if calls == 1 {
+					return nil, errors.New("server build failed")
+				}
+				return &http.Server{}, nil
+			}, di.RetryOnResolve()),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.Error(t, c.Resolve(&server))
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("resolve with cached build error", func(t *testing.T) {
+		var calls int
+		c, err := di.New(
+			di.Provide(func() (*http.ServeMux, error) {
+				calls++
+				return nil, errors.New("mux build failed")
+			}, di.CacheError(), di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		require.Error(t, c.Resolve(&mux))
+		var handler http.Handler
+		err = c.Resolve(&handler)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "mux build failed")
+		require.Equal(t, 1, calls)
+	})
+
 	t.Run("resolve with failed dependency build", func(t *testing.T) {
 		c, err := di.New()
 		require.NoError(t, err)
@@ -353,6 +481,31 @@ func TestContainer_Resolve(t *testing.T) {
 		require.Equal(t, []string{"fn1", "fn2", "fn3"}, result)
 	})
 
+	t.Run("build info provided by default", func(t *testing.T) {
+		var info di.BuildInfo
+		c, err := di.New()
+		require.NoError(t, err)
+		require.NoError(t, c.Resolve(&info))
+		require.Equal(t, runtime.Version(), info.GoVersion)
+	})
+
+	t.Run("linker variables provided as named strings", func(t *testing.T) {
+		version, commit, region := "v1.2.0", "", "eu"
+		c, err := di.New(
+			di.LinkerVars(map[string]*string{"version": &version, "commit": &commit, "region": &region}),
+		)
+		require.NoError(t, err)
+		var info di.BuildInfo
+		require.NoError(t, c.Resolve(&info))
+		var v string
+		require.NoError(t, c.Resolve(&v, di.Name("version")))
+		require.Equal(t, "v1.2.0", v)
+		require.NoError(t, c.Resolve(&v, di.Name("commit")))
+		require.Equal(t, info.Commit, v)
+		require.NoError(t, c.Resolve(&v, di.Name("region")))
+		require.Equal(t, "eu", v)
+	})
+
 	t.Run("container provided by default", func(t *testing.T) {
 		var container *di.Container
 		c, err := di.New()
@@ -376,7 +529,7 @@ func TestContainer_Resolve(t *testing.T) {
 		err = c.Resolve(&b)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "container_test.go:")
-		require.Contains(t, err.Error(), ": cycle detected") // todo: improve message
+		require.Regexp(t, `: cycle detected: bool \(.+container_test.go:\d+\) -> int32 \(.+container_test.go:\d+\) -> int64 \(.+container_test.go:\d+\) -> bool$`, err.Error())
 	})
 
 	//t.Run("first resolve checks graph correctness", func(t *testing.T) {
@@ -417,6 +570,58 @@ func TestContainer_Resolve(t *testing.T) {
 	})
 }
 
+func TestContainer_ResolveAll(t *testing.T) {
+	t.Run("resolve all targets", func(t *testing.T) {
+		server := &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(server),
+			di.Provide(http.NewServeMux, di.Tags{"name": "mux"}),
+		)
+		require.NoError(t, err)
+		var s *http.Server
+		var mux *http.ServeMux
+		require.NoError(t, c.ResolveAll(&s, &mux))
+		require.Same(t, server, s)
+		require.NotNil(t, mux)
+	})
+
+	t.Run("unresolvable targets reported together without construction", func(t *testing.T) {
+		var built bool
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux { built = true; return http.NewServeMux() }),
+			di.Provide(func(server *http.Server) *http.Client { return &http.Client{} }),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		var client *http.Client
+		var conn net.Conn
+		err = c.ResolveAll(&mux, &client, &conn)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.Contains(t, err.Error(), "*http.Client: type *http.Server not exists in the container")
+		require.Contains(t, err.Error(), "type net.Conn not exists in the container")
+		var verr *di.VerificationError
+		require.True(t, errors.As(err, &verr))
+		require.Len(t, verr.Problems, 2)
+		require.False(t, built)
+		require.Nil(t, mux)
+	})
+
+	t.Run("targets not assigned if construction failed", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux),
+			di.Provide(func() (*http.Server, error) { return nil, errors.New("server error") }),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		var server *http.Server
+		err = c.ResolveAll(&mux, &server)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "*http.Server: server error")
+		require.Nil(t, mux)
+	})
+}
+
 func TestContainer_Decorate(t *testing.T) {
 	t.Run("decorate provide", func(t *testing.T) {
 		c, err := di.New()
@@ -678,6 +883,124 @@ func TestContainer_Groups(t *testing.T) {
 		require.NoError(t, c.Resolve(&conn))
 		require.Equal(t, fmt.Sprintf("%p", conn), fmt.Sprintf("%p", conn))
 	})
+
+	t.Run("group members sorted by order", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{Addr: "first"}),
+			di.ProvideValue(&http.Server{Addr: "last"}, di.WithOrder(10)),
+			di.ProvideValue(&http.Server{Addr: "second"}),
+			di.ProvideValue(&http.Server{Addr: "zero"}, di.WithOrder(-1)),
+		)
+		require.NoError(t, err)
+		var servers []*http.Server
+		require.NoError(t, c.Resolve(&servers))
+		var addrs []string
+		for _, server := range servers {
+			addrs = append(addrs, server.Addr)
+		}
+		require.Equal(t, []string{"zero", "first", "second", "last"}, addrs)
+	})
+
+	t.Run("interface group members sorted by order", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }, di.As(new(io.Closer)), di.WithOrder(2)),
+			di.Provide(func() *os.File { return os.Stdin }, di.As(new(io.Closer)), di.WithOrder(1)),
+		)
+		require.NoError(t, err)
+		var closers []io.Closer
+		require.NoError(t, c.Resolve(&closers))
+		require.Len(t, closers, 2)
+		require.IsType(t, &os.File{}, closers[0])
+	})
+
+	t.Run("named providers resolved into map", func(t *testing.T) {
+		public, private := &http.Server{}, &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(public, di.WithName("public")),
+			di.ProvideValue(private, di.WithName("private")),
+			di.ProvideValue(&http.Server{}),
+		)
+		require.NoError(t, err)
+		var servers map[string]*http.Server
+		require.NoError(t, c.Resolve(&servers))
+		require.Len(t, servers, 2)
+		require.Equal(t, fmt.Sprintf("%p", public), fmt.Sprintf("%p", servers["public"]))
+		require.Equal(t, fmt.Sprintf("%p", private), fmt.Sprintf("%p", servers["private"]))
+	})
+
+	t.Run("named interface providers resolved into map", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler)), di.WithName("mux")),
+			di.Provide(func() http.HandlerFunc { return func(http.ResponseWriter, *http.Request) {} }, di.As(new(http.Handler)), di.WithName("func")),
+		)
+		require.NoError(t, err)
+		var handlers map[string]http.Handler
+		require.NoError(t, c.Resolve(&handlers))
+		require.Len(t, handlers, 2)
+		require.IsType(t, &http.ServeMux{}, handlers["mux"])
+	})
+
+	t.Run("named group without named providers cause error", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}),
+		)
+		require.NoError(t, err)
+		var servers map[string]*http.Server
+		err = c.Resolve(&servers)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.Contains(t, err.Error(), "type map[string]*http.Server not exists in the container")
+	})
+
+	t.Run("named group with duplicate names cause error", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "public", "port": "80"}),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "public", "port": "443"}),
+		)
+		require.NoError(t, err)
+		var servers map[string]*http.Server
+		err = c.Resolve(&servers)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "multiple definitions of *http.Server with name public")
+		require.NoError(t, c.Resolve(&servers, di.Tags{"port": "443"}))
+		require.Len(t, servers, 1)
+	})
+	t.Run("filter group members by tags", func(t *testing.T) {
+		admin := http.NewServeMux()
+		c, err := di.New(
+			di.ProvideValue(admin, di.As(new(http.Handler)), di.Tags{"role": "admin", "name": "admin"}),
+			di.ProvideValue(http.NewServeMux(), di.As(new(http.Handler)), di.Tags{"role": "public", "name": "public"}),
+			// definition of group type is not matched by filter
+			di.ProvideValue([]http.Handler{}, di.Tags{"role": "admin"}),
+		)
+		require.NoError(t, err)
+		var handlers []http.Handler
+		require.NoError(t, c.Resolve(&handlers, di.FilterTags(di.Tags{"role": "admin"})))
+		require.Equal(t, []http.Handler{admin}, handlers)
+		var named map[string]http.Handler
+		require.NoError(t, c.Resolve(&named, di.FilterTags(di.Tags{"role": "admin"})))
+		require.Equal(t, map[string]http.Handler{"admin": admin}, named)
+		var iterated []di.Tags
+		require.NoError(t, c.Iterate(&handlers, func(tags di.Tags, loader di.ValueFunc) error {
+			iterated = append(iterated, tags)
+			return nil
+		}, di.FilterTags(di.Tags{"role": "public"})))
+		require.Equal(t, []di.Tags{{"role": "public", "name": "public"}}, iterated)
+		require.NoError(t, c.Resolve(&handlers, di.FilterTags(di.Tags{"role": "unknown"})))
+		require.Empty(t, handlers)
+	})
+
+	t.Run("filter non-group type cause error", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(http.NewServeMux(), di.Tags{"role": "admin"}),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		err = c.Resolve(&mux, di.FilterTags(di.Tags{"role": "admin"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.Contains(t, err.Error(), "*http.ServeMux|[role:admin]: tags filter can be used with groups only")
+	})
 }
 
 func TestContainer_Iterate(t *testing.T) {
@@ -699,15 +1022,46 @@ func TestContainer_Iterate(t *testing.T) {
 		})
 		require.EqualError(t, err, "target must be a pointer, got http.ServeMux")
 	})
-	t.Run("iterate over struct causes error", func(t *testing.T) {
+	t.Run("iterate over struct", func(t *testing.T) {
 		c, err := di.New()
 		require.NoError(t, err)
 		require.NotNil(t, c)
 		require.NoError(t, c.Provide(func() http.ServeMux { return http.ServeMux{} }))
+		var count int
 		err = c.Iterate(&http.ServeMux{}, func(tags di.Tags, loader di.ValueFunc) error {
+			count++
 			return nil
 		})
-		require.EqualError(t, err, "iteration can be used with groups only")
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+	t.Run("iterate over definitions that implement interface", func(t *testing.T) {
+		server := &http.Server{}
+		file := &os.File{}
+		c, err := di.New(
+			di.ProvideValue(server, di.As(new(io.Closer)), di.Tags{"kind": "server"}),
+			di.ProvideValue(file, di.Tags{"kind": "file"}),
+			di.ProvideValue(http.NewServeMux()),
+		)
+		require.NoError(t, err)
+		var closers []io.Closer
+		var tags []di.Tags
+		iterFn := func(t di.Tags, loader di.ValueFunc) error {
+			v, err := loader()
+			if err != nil {
+				return err
+			}
+			tags = append(tags, t)
+			closers = append(closers, v.(io.Closer))
+			return nil
+		}
+		var closer io.Closer
+		require.NoError(t, c.Iterate(&closer, iterFn))
+		require.Equal(t, []io.Closer{server, file}, closers)
+		require.Equal(t, []di.Tags{{"kind": "server"}, {"kind": "file"}}, tags)
+		closers = nil
+		require.NoError(t, c.Iterate(&closer, iterFn, di.Tags{"kind": "server"}))
+		require.Equal(t, []io.Closer{server}, closers)
 	})
 	t.Run("iterates over instances", func(t *testing.T) {
 		c, err := di.New()
@@ -923,6 +1277,29 @@ func TestContainer_Tags(t *testing.T) {
 		)
 		require.NoError(t, err)
 	})
+
+	t.Run("resolve group with exact option and unique match", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.Tags{"server": "one"}),
+			di.Provide(http.NewServeMux, di.Tags{"server": "two"}),
+		)
+		require.NoError(t, err)
+		var muxs []*http.ServeMux
+		require.NoError(t, c.Resolve(&muxs, di.Tags{"server": "one"}, di.Exact()))
+		require.Len(t, muxs, 1)
+	})
+
+	t.Run("resolve group with exact option and multiple matches cause error", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.Tags{"server": "one"}),
+			di.Provide(http.NewServeMux, di.Tags{"server": "two"}),
+		)
+		require.NoError(t, err)
+		var muxs []*http.ServeMux
+		err = c.Resolve(&muxs, di.Tags{"server": "*"}, di.Exact())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "multiple definitions of *http.ServeMux[server:*], exact match required")
+	})
 }
 
 func TestContainer_Group(t *testing.T) {
@@ -1043,7 +1420,52 @@ func TestContainer_Invoke(t *testing.T) {
 		err = c.Invoke(func(bool) {})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "container_test.go:")
-		require.Contains(t, err.Error(), ": cycle detected") // todo: improve message
+		require.Regexp(t, `: cycle detected: bool \(.+container_test.go:\d+\) -> int32 \(.+container_test.go:\d+\) -> int64 \(.+container_test.go:\d+\) -> bool$`, err.Error())
+	})
+}
+
+func TestContainer_InvokeMethod(t *testing.T) {
+	t.Run("method called on resolved receiver", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(bytes.NewBufferString("data")),
+		)
+		require.NoError(t, err)
+		var buf *bytes.Buffer
+		require.NoError(t, c.InvokeMethod(&buf, "Reset"))
+		require.Equal(t, 0, buf.Len())
+	})
+
+	t.Run("method parameters resolved", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&strings.Builder{}),
+			di.ProvideValue(64),
+		)
+		require.NoError(t, err)
+		var builder *strings.Builder
+		require.NoError(t, c.InvokeMethod(&builder, "Grow"))
+		require.GreaterOrEqual(t, builder.Cap(), 64)
+	})
+
+	t.Run("method not found", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&bytes.Buffer{}),
+		)
+		require.NoError(t, err)
+		var buf *bytes.Buffer
+		err = c.InvokeMethod(&buf, "Start")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.Contains(t, err.Error(), ": invalid invocation signature, *bytes.Buffer has no method Start")
+	})
+
+	t.Run("receiver not exists", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		var buf *bytes.Buffer
+		err = c.InvokeMethod(&buf, "Reset")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.Contains(t, err.Error(), "type *bytes.Buffer not exists in the container")
 	})
 }
 
@@ -1107,6 +1529,45 @@ func TestContainer_Has(t *testing.T) {
 	})
 }
 
+func TestContainer_HasAll(t *testing.T) {
+	c, err := di.New(
+		di.Provide(http.NewServeMux),
+	)
+	require.NoError(t, err)
+
+	t.Run("all targets exist", func(t *testing.T) {
+		var mux *http.ServeMux
+		ok, missing := c.HasAll(&mux)
+		require.True(t, ok)
+		require.Empty(t, missing)
+	})
+
+	t.Run("missing targets reported", func(t *testing.T) {
+		var mux *http.ServeMux
+		var server *http.Server
+		var client *http.Client
+		ok, missing := c.HasAll(&server, &mux, &client, nil)
+		require.False(t, ok)
+		require.Len(t, missing, 3)
+		require.Equal(t, reflect.TypeOf(server), missing[0].Type)
+		require.True(t, errors.Is(missing[0].Err, di.ErrTypeNotExists))
+		require.Equal(t, reflect.TypeOf(client), missing[1].Type)
+		require.Nil(t, missing[2].Type)
+		require.EqualError(t, missing[2].Err, "target must be a pointer, got nil")
+	})
+
+	t.Run("any target exists", func(t *testing.T) {
+		var mux *http.ServeMux
+		var server *http.Server
+		ok, missing := c.HasAny(&server, &mux)
+		require.True(t, ok)
+		require.Len(t, missing, 1)
+		ok, missing = c.HasAny(&server)
+		require.False(t, ok)
+		require.Len(t, missing, 1)
+	})
+}
+
 func TestContainer_Inject(t *testing.T) {
 	t.Run("inject into provided struct pointer with di.Inject", func(t *testing.T) {
 		c, err := di.New()
@@ -1260,7 +1721,7 @@ func TestContainer_Inject(t *testing.T) {
 		err = c.Resolve(&result)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "container_test.go:")
-		require.Contains(t, err.Error(), ": cycle detected")
+		require.Regexp(t, `: cycle detected: \*di_test.InjectableType \(.+\) -> string \(.+\) -> \*di_test.InjectableType$`, err.Error())
 	})
 
 	t.Run("optional parameter may be nil (deprecated)", func(t *testing.T) {
@@ -1401,6 +1862,7 @@ func TestContainer_Inject(t *testing.T) {
 			private []http.Handler
 			Addrs   []net.Addr     `di:"optional"`
 			Skipped *http.ServeMux `di:"skip"`
+			Dashed  *http.ServeMux `di:"-"`
 		}
 		type InjectableType struct {
 			di.Inject
@@ -1418,9 +1880,26 @@ func TestContainer_Inject(t *testing.T) {
 		require.NoError(t, c.Resolve(&result))
 
 		mux := http.NewServeMux()
-		p := InjectableParameter{Skipped: mux}
+		p := InjectableParameter{Skipped: mux, Dashed: mux}
 		require.NoError(t, c.Resolve(&p))
-		require.Equal(t, InjectableParameter{Skipped: mux}, p)
+		require.Equal(t, InjectableParameter{Skipped: mux, Dashed: mux}, p)
+	})
+
+	t.Run("name and optional combined in one tag", func(t *testing.T) {
+		type Parameter struct {
+			di.Inject
+			Primary   *http.Server `di:"name=primary,optional"`
+			Secondary *http.Server `di:"name=secondary, optional"`
+		}
+		primary := &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(primary, di.Tags{"name": "primary"}),
+		)
+		require.NoError(t, err)
+		var p Parameter
+		require.NoError(t, c.Resolve(&p))
+		require.Same(t, primary, p.Primary)
+		require.Nil(t, p.Secondary)
 	})
 
 	t.Run("resolving not provided injectable cause error", func(t *testing.T) {
@@ -1507,6 +1986,60 @@ func TestContainer_Inject(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, fmt.Sprintf("%p", mux), fmt.Sprintf("%p", ip.Mux))
 	})
+
+	t.Run("resolve struct with interface and interface group fields", func(t *testing.T) {
+		type Handlers struct {
+			di.Inject
+			Private http.Handler          `di:"type=private"`
+			Public  []http.Handler        `di:"type=public"`
+			All     []http.Handler        `di:""`
+			Writers []http.ResponseWriter `di:"optional"`
+		}
+		private := &http.ServeMux{}
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux { return &http.ServeMux{} }, di.As(new(http.Handler)), di.Tags{"type": "public"}),
+			di.Provide(func() *http.ServeMux { return private }, di.As(new(http.Handler)), di.Tags{"type": "private"}),
+			di.Provide(func() *http.ServeMux { return &http.ServeMux{} }, di.As(new(http.Handler)), di.Tags{"type": "public", "version": "2"}),
+		)
+		require.NoError(t, err)
+		var handlers Handlers
+		require.NoError(t, c.Resolve(&handlers))
+		require.Equal(t, fmt.Sprintf("%p", private), fmt.Sprintf("%p", handlers.Private))
+		require.Len(t, handlers.Public, 2)
+		require.Len(t, handlers.All, 3)
+		require.Nil(t, handlers.Writers)
+	})
+
+	t.Run("inject with custom tag name", func(t *testing.T) {
+		type Application struct {
+			di.Inject
+			Public  *http.Server `inject:"name=public" di:"ignored"`
+			Private *http.Server `inject:"name=private"`
+			Mux     *http.ServeMux
+			Client  *http.Client `inject:"optional"`
+		}
+		public := &http.Server{}
+		private := &http.Server{}
+		mux := http.NewServeMux()
+		c, err := di.New(
+			di.WithTagName("inject"),
+			di.ProvideValue(public, di.Tags{"name": "public"}),
+			di.ProvideValue(private, di.Tags{"name": "private"}),
+			di.ProvideValue(mux, di.Tags{"name": "mux"}),
+		)
+		require.NoError(t, err)
+		var app Application
+		require.NoError(t, c.Resolve(&app))
+		require.Same(t, public, app.Public)
+		require.Same(t, private, app.Private)
+		require.Same(t, mux, app.Mux)
+		require.Nil(t, app.Client)
+		child, err := c.NewChild()
+		require.NoError(t, err)
+		var fromChild Application
+		require.NoError(t, child.Resolve(&fromChild))
+		require.Same(t, public, fromChild.Public)
+	})
 }
 
 func TestContainer_Cleanup(t *testing.T) {
@@ -1616,3 +2149,388 @@ func TestContainer_AddParent(t *testing.T) {
 	})
 
 }
+
+func TestContainer_NewChild(t *testing.T) {
+	t.Run("child resolves own definitions first", func(t *testing.T) {
+		parentServer := &http.Server{}
+		childServer := &http.Server{}
+		parent, err := di.New(
+			di.ProvideValue(parentServer),
+			di.Provide(http.NewServeMux),
+		)
+		require.NoError(t, err)
+		child, err := parent.NewChild(
+			di.ProvideValue(childServer),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, child.Resolve(&server))
+		require.Equal(t, fmt.Sprintf("%p", childServer), fmt.Sprintf("%p", server))
+		require.NoError(t, parent.Resolve(&server))
+		require.Equal(t, fmt.Sprintf("%p", parentServer), fmt.Sprintf("%p", server))
+		var mux *http.ServeMux
+		require.NoError(t, child.Resolve(&mux))
+		require.Empty(t, child.Warnings())
+	})
+
+	t.Run("parent singleton resolved by child built with parent dependencies", func(t *testing.T) {
+		parentMux := http.NewServeMux()
+		parent, err := di.New(
+			di.ProvideValue(parentMux),
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }),
+		)
+		require.NoError(t, err)
+		child, err := parent.NewChild(
+			di.ProvideValue(http.NewServeMux()),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, child.Resolve(&server))
+		require.Equal(t, fmt.Sprintf("%p", parentMux), fmt.Sprintf("%p", server.Handler))
+		require.NoError(t, parent.Resolve(&server))
+		require.Equal(t, fmt.Sprintf("%p", parentMux), fmt.Sprintf("%p", server.Handler))
+	})
+
+	t.Run("child resolves itself as container", func(t *testing.T) {
+		parent, err := di.New()
+		require.NoError(t, err)
+		child, err := parent.NewChild()
+		require.NoError(t, err)
+		var c *di.Container
+		require.NoError(t, child.Resolve(&c))
+		require.Equal(t, fmt.Sprintf("%p", child), fmt.Sprintf("%p", c))
+	})
+
+	t.Run("child definitions are not visible in parent", func(t *testing.T) {
+		parent, err := di.New()
+		require.NoError(t, err)
+		_, err = parent.NewChild(di.Provide(http.NewServeMux))
+		require.NoError(t, err)
+		has, err := parent.Has(new(*http.ServeMux))
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("child cleanup does not affect parent", func(t *testing.T) {
+		var cleaned []string
+		parent, err := di.New(
+			di.Provide(func() (*http.Server, func()) {
+				return &http.Server{}, func() { cleaned = append(cleaned, "parent") }
+			}),
+		)
+		require.NoError(t, err)
+		child, err := parent.NewChild(
+			di.Provide(func(server *http.Server) (*http.ServeMux, func()) {
+				return &http.ServeMux{}, func() { cleaned = append(cleaned, "child") }
+			}),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		require.NoError(t, child.Resolve(&mux))
+		child.Cleanup()
+		require.Equal(t, []string{"child"}, cleaned)
+		parent.Cleanup()
+		require.Equal(t, []string{"child", "parent"}, cleaned)
+	})
+
+	t.Run("child options error", func(t *testing.T) {
+		parent, err := di.New()
+		require.NoError(t, err)
+		_, err = parent.NewChild(di.Provide(nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.Contains(t, err.Error(), "invalid constructor signature, got nil")
+	})
+}
+
+func TestContainer_Inspection(t *testing.T) {
+	t.Run("count definitions", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }),
+			di.Provide(func() *http.ServeMux { return &http.ServeMux{} }, di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		// container, build info, server, mux and handler
+		require.Equal(t, 5, c.NumDefinitions())
+	})
+
+	t.Run("types", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }),
+			di.Provide(func() *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []reflect.Type{
+			reflect.TypeOf(new(di.Container)),
+			reflect.TypeOf(new(http.Server)),
+			reflect.TypeOf(di.BuildInfo{}),
+		}, c.Types())
+	})
+
+	t.Run("tagged types", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"type": "public"}),
+			di.Provide(func() *http.ServeMux { return &http.ServeMux{} }),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []reflect.Type{reflect.TypeOf(new(http.Server))}, c.Tagged(di.Tags{"type": "public"}))
+		require.Empty(t, c.Tagged(di.Tags{"type": "private"}))
+	})
+
+	t.Run("definitions contain declared dependencies", func(t *testing.T) {
+		type Handler struct {
+			di.Inject
+			Mux    *http.ServeMux `di:"name=public"`
+			Logger *log.Logger    `di:"optional"`
+		}
+		c, err := di.New(
+			// dependencies are not provided
+			di.Provide(func(handler *Handler) *http.Server { return &http.Server{Handler: handler.Mux} }),
+			di.Provide(func() *Handler { return &Handler{} }),
+		)
+		require.NoError(t, err)
+		defs := c.Definitions()
+		require.Len(t, defs, 4)
+		require.Equal(t, reflect.TypeOf(new(Handler)), defs[1].Type)
+		require.Equal(t, []di.TypeRef{
+			{Type: reflect.TypeOf(new(http.ServeMux)), Tags: di.Tags{"name": "public"}},
+			{Type: reflect.TypeOf(new(log.Logger)), Tags: di.Tags{}, Optional: true},
+		}, defs[1].Dependencies)
+		require.Equal(t, reflect.TypeOf(new(http.Server)), defs[2].Type)
+		require.Len(t, defs[2].Dependencies, 1)
+		require.Equal(t, "*di_test.Handler", defs[2].Dependencies[0].String())
+	})
+
+	t.Run("providers contain source locations", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler)), di.WithLifetime(di.Scoped)),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "public"}),
+		)
+		require.NoError(t, err)
+		providers := c.Providers()
+		require.Len(t, providers, 2)
+		require.Equal(t, reflect.TypeOf(new(http.ServeMux)), providers[0].Type)
+		require.Equal(t, []reflect.Type{reflect.TypeOf(new(http.Handler)).Elem()}, providers[0].Interfaces)
+		require.Equal(t, di.Scoped, providers[0].Lifetime)
+		require.Contains(t, providers[0].Source, "container_test.go:")
+		require.Equal(t, di.Tags{"name": "public"}, providers[1].Tags)
+		require.Contains(t, providers[1].Source, "container_test.go:")
+	})
+
+	t.Run("fingerprint changes only with wiring", func(t *testing.T) {
+		newContainer := func(options ...di.ProvideOption) *di.Container {
+			c, err := di.New(
+				di.Provide(http.NewServeMux, append([]di.ProvideOption{di.As(new(http.Handler))}, options...)...),
+				di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }),
+			)
+			require.NoError(t, err)
+			return c
+		}
+		first := newContainer()
+		moved, err := di.New(
+			di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }),
+			di.Provide(http.NewServeMux, di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		require.Len(t, first.Fingerprint(), 64)
+		require.Equal(t, first.Fingerprint(), newContainer().Fingerprint())
+		require.Equal(t, first.Fingerprint(), moved.Fingerprint())
+		require.NotEqual(t, first.Fingerprint(), newContainer(di.WithLifetime(di.Scoped)).Fingerprint())
+		require.NotEqual(t, first.Fingerprint(), newContainer(di.Tags{"name": "api"}).Fingerprint())
+		require.NoError(t, first.Provide(func() *http.Client { return &http.Client{} }))
+		require.NotEqual(t, moved.Fingerprint(), first.Fingerprint())
+	})
+}
+
+func TestContainer_ResolveNamedType(t *testing.T) {
+	t.Run("resolve by type name", func(t *testing.T) {
+		server := &http.Server{}
+		c, err := di.New(
+			di.Provide(func() *http.Server { return server }),
+		)
+		require.NoError(t, err)
+		v, err := c.ResolveNamedType("*http.Server")
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%p", server), fmt.Sprintf("%p", v))
+	})
+
+	t.Run("resolve by tagged type name", func(t *testing.T) {
+		public := &http.Server{}
+		c, err := di.New(
+			di.Provide(func() *http.Server { return public }, di.Tags{"type": "public"}),
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"type": "private"}),
+		)
+		require.NoError(t, err)
+		v, err := c.ResolveNamedType("*http.Server[type:public]")
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%p", public), fmt.Sprintf("%p", v))
+		servers, err := c.ResolveNamedType("[]*http.Server")
+		require.NoError(t, err)
+		require.Len(t, servers, 2)
+	})
+
+	t.Run("resolve not existing type name cause error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		_, err = c.ResolveNamedType("*http.Server")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.Contains(t, err.Error(), ": type *http.Server not exists in the container")
+	})
+
+	t.Run("resolve ambiguous type name cause error", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(htmltemplate.New("html")),
+			di.ProvideValue(texttemplate.New("text")),
+		)
+		require.NoError(t, err)
+		_, err = c.ResolveNamedType("*template.Template")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.Contains(t, err.Error(), "type name *template.Template is ambiguous")
+		require.Contains(t, err.Error(), "*html/template.Template")
+		require.Contains(t, err.Error(), "*text/template.Template")
+	})
+}
+
+func TestContainer_OnGroupChange(t *testing.T) {
+	t.Run("subscriber notified about new members", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux { return &http.ServeMux{} }, di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		var handlers []http.Handler
+		require.NoError(t, c.Resolve(&handlers))
+		require.Len(t, handlers, 1)
+		var changes []di.Tags
+		require.NoError(t, c.OnGroupChange(&handlers, func(tags di.Tags) {
+			changes = append(changes, tags)
+		}))
+		require.NoError(t, c.Provide(func() *http.ServeMux { return &http.ServeMux{} }, di.As(new(http.Handler)), di.Tags{"type": "late"}))
+		require.Equal(t, []di.Tags{{"type": "late"}}, changes)
+		require.NoError(t, c.Resolve(&handlers))
+		require.Len(t, handlers, 2)
+	})
+
+	t.Run("subscribe on not group cause error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		err = c.OnGroupChange(new(http.Handler), func(tags di.Tags) {})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "container_test.go:")
+		require.Contains(t, err.Error(), ": target must be a pointer to slice, got *http.Handler")
+	})
+}
+
+func TestContainer_ApplyNamespaced(t *testing.T) {
+	t.Run("same options applied twice", func(t *testing.T) {
+		var ordersServer, eventsServer *http.Server
+		module := func(resolved **http.Server) di.Option {
+			return di.Options(
+				di.Provide(func() *http.Server { return &http.Server{} }),
+				di.ProvideValue(&http.ServeMux{}, di.WithName("mux")),
+				di.Resolve(resolved),
+			)
+		}
+		c, err := di.New()
+		require.NoError(t, err)
+		require.NoError(t, c.ApplyNamespaced("orders", module(&ordersServer)))
+		require.NoError(t, c.ApplyNamespaced("events", module(&eventsServer)))
+		require.NotNil(t, ordersServer)
+		require.NotNil(t, eventsServer)
+		require.NotEqual(t, fmt.Sprintf("%p", ordersServer), fmt.Sprintf("%p", eventsServer))
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server, di.Tags{"name": "orders"}))
+		require.Equal(t, fmt.Sprintf("%p", ordersServer), fmt.Sprintf("%p", server))
+		var mux *http.ServeMux
+		require.NoError(t, c.Resolve(&mux, di.Tags{"name": "events.mux"}))
+	})
+}
+
+func TestInstantiate(t *testing.T) {
+	t.Run("module dependencies resolved to qualified versions", func(t *testing.T) {
+		module := di.Options(
+			di.Provide(http.NewServeMux),
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }),
+		)
+		var invoked []*http.Server
+		c, err := di.New(
+			di.Instantiate(module, "orders"),
+			di.Instantiate(di.Options(
+				module,
+				di.Invoke(func(server *http.Server) { invoked = append(invoked, server) }),
+			), "events"),
+		)
+		require.NoError(t, err)
+		var ordersServer, eventsServer *http.Server
+		var ordersMux, eventsMux *http.ServeMux
+		require.NoError(t, c.Resolve(&ordersServer, di.Tags{"name": "orders"}))
+		require.NoError(t, c.Resolve(&eventsServer, di.Tags{"name": "events"}))
+		require.NoError(t, c.Resolve(&ordersMux, di.Tags{"name": "orders"}))
+		require.NoError(t, c.Resolve(&eventsMux, di.Tags{"name": "events"}))
+		require.Equal(t, fmt.Sprintf("%p", ordersMux), fmt.Sprintf("%p", ordersServer.Handler))
+		require.Equal(t, fmt.Sprintf("%p", eventsMux), fmt.Sprintf("%p", eventsServer.Handler))
+		require.Equal(t, []*http.Server{eventsServer}, invoked)
+	})
+
+	t.Run("dependencies outside of module resolved as is", func(t *testing.T) {
+		mux := &http.ServeMux{}
+		c, err := di.New(
+			di.ProvideValue(mux),
+			di.Instantiate(di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }), "orders"),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server, di.Tags{"name": "orders"}))
+		require.Equal(t, fmt.Sprintf("%p", mux), fmt.Sprintf("%p", server.Handler))
+	})
+}
+
+func BenchmarkContainer_Invoke(b *testing.B) {
+	c, err := di.New(
+		di.Provide(func() *http.ServeMux { return &http.ServeMux{} }, di.As(new(http.Handler))),
+		di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }),
+	)
+	require.NoError(b, err)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Invoke(func(server *http.Server, handlers []http.Handler) {}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkContainer_Resolve(b *testing.B) {
+	b.Run("singleton", func(b *testing.B) {
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux { return &http.ServeMux{} }, di.As(new(http.Handler))),
+			di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }),
+		)
+		require.NoError(b, err)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var server *http.Server
+			if err := c.Resolve(&server); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("transient", func(b *testing.B) {
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux { return &http.ServeMux{} }, di.As(new(http.Handler))),
+			di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }, di.WithLifetime(di.Transient)),
+		)
+		require.NoError(b, err)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var server *http.Server
+			if err := c.Resolve(&server); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
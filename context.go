@@ -0,0 +1,80 @@
+package di
+
+import (
+	"context"
+	"fmt"
+)
+
+// containerKey is a context key of container.
+type containerKey struct{}
+
+// ContextWithContainer returns copy of ctx that carries container. Use it with
+// Container.NewChild() and di.Scoped lifetime to resolve per request instances.
+//
+//	func (m *Middleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+//		ctx, end, err := m.container.Scope(r.Context(), di.ProvideValue(r))
+//		if err != nil {
+//			// handle error
+//		}
+//		defer end()
+//		m.next.ServeHTTP(w, r.WithContext(ctx))
+//	}
+func ContextWithContainer(ctx context.Context, c *Container) context.Context {
+	return context.WithValue(ctx, containerKey{}, c)
+}
+
+// FromContext returns container stored in ctx by ContextWithContainer().
+//
+//	c, ok := di.FromContext(r.Context())
+//	if !ok {
+//		// handle missing scope
+//	}
+//	var logger *RequestLogger
+//	if err := c.Resolve(&logger); err != nil {
+//		// handle error
+//	}
+func FromContext(ctx context.Context) (*Container, bool) {
+	c, ok := ctx.Value(containerKey{}).(*Container)
+	return c, ok
+}
+
+// Scope creates child container with options and returns copy of ctx that carries it. The
+// di.Scoped definitions are instantiated once per scope. The end function cleanups the scope.
+func (c *Container) Scope(ctx context.Context, options ...Option) (_ context.Context, end func(), err error) {
+	child, err := c.NewChild(options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	child.scopeCtx = ctx
+	return ContextWithContainer(ctx, child), child.Cleanup, nil
+}
+
+// FromCtx returns container option that provides value of type T stored by key in context of
+// scope created by Container.Scope(). The value is di.Scoped, so each scope resolves value of
+// its own context. Resolve outside of scope or without value in context causes error.
+//
+//	container, err := di.New(
+//		di.FromCtx[TraceID](traceIDKey{}),
+//		di.FromCtx[*Principal](principalKey{}),
+//		di.Provide(NewAuditLogger, di.WithLifetime(di.Scoped)), // depends on TraceID and *Principal
+//	)
+func FromCtx[T any](key interface{}, options ...ProvideOption) Option {
+	frame := stacktrace(0)
+	return option(func(c *diopts) {
+		c.provides = append(c.provides, provideOptions{
+			frame,
+			func(scope *Container) (T, error) {
+				var zero T
+				if scope.scopeCtx == nil {
+					return zero, fmt.Errorf("context value %v resolved outside of scope", key)
+				}
+				value, ok := scope.scopeCtx.Value(key).(T)
+				if !ok {
+					return zero, fmt.Errorf("context value %v not found", key)
+				}
+				return value, nil
+			},
+			append([]ProvideOption{WithLifetime(Scoped)}, options...),
+		})
+	})
+}
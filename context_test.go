@@ -0,0 +1,105 @@
+package di_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Run("container stored in context", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		ctx := di.ContextWithContainer(context.Background(), c)
+		stored, ok := di.FromContext(ctx)
+		require.True(t, ok)
+		require.Equal(t, fmt.Sprintf("%p", c), fmt.Sprintf("%p", stored))
+	})
+
+	t.Run("context without container", func(t *testing.T) {
+		_, ok := di.FromContext(context.Background())
+		require.False(t, ok)
+	})
+}
+
+func TestContainer_Scope(t *testing.T) {
+	t.Run("scoped instances created once per scope and cleaned up on scope end", func(t *testing.T) {
+		var created, cleaned int
+		c, err := di.New(
+			di.Provide(func(server *http.Server) (*http.Request, func(), error) {
+				created++
+				req, err := http.NewRequest(http.MethodGet, "/"+server.Addr, nil)
+				return req, func() { cleaned++ }, err
+			}, di.WithLifetime(di.Scoped)),
+		)
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			ctx, end, err := c.Scope(context.Background(), di.ProvideValue(&http.Server{Addr: fmt.Sprint(i)}))
+			require.NoError(t, err)
+			scope, ok := di.FromContext(ctx)
+			require.True(t, ok)
+			var first, second *http.Request
+			require.NoError(t, scope.Resolve(&first))
+			require.NoError(t, scope.Resolve(&second))
+			require.Equal(t, fmt.Sprintf("/%d", i), first.URL.Path)
+			require.Equal(t, fmt.Sprintf("%p", first), fmt.Sprintf("%p", second))
+			end()
+		}
+		require.Equal(t, 2, created)
+		require.Equal(t, 2, cleaned)
+	})
+
+	t.Run("scope options error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		_, _, err = c.Scope(context.Background(), di.Provide(nil))
+		require.Error(t, err)
+	})
+
+	t.Run("context values provided into scope", func(t *testing.T) {
+		type traceIDKey struct{}
+		type TraceID string
+		c, err := di.New(
+			di.FromCtx[TraceID](traceIDKey{}),
+			di.Provide(func(id TraceID) *http.Request {
+				return &http.Request{Header: http.Header{"X-Trace-Id": []string{string(id)}}}
+			}, di.WithLifetime(di.Scoped)),
+		)
+		require.NoError(t, err)
+		for _, id := range []TraceID{"first", "second"} {
+			ctx, end, err := c.Scope(context.WithValue(context.Background(), traceIDKey{}, id))
+			require.NoError(t, err)
+			scope, _ := di.FromContext(ctx)
+			var req *http.Request
+			require.NoError(t, scope.Resolve(&req))
+			require.Equal(t, string(id), req.Header.Get("X-Trace-Id"))
+			end()
+		}
+	})
+
+	t.Run("context value not found", func(t *testing.T) {
+		type traceIDKey struct{}
+		type TraceID string
+		c, err := di.New(
+			di.FromCtx[TraceID](traceIDKey{}),
+		)
+		require.NoError(t, err)
+		var id TraceID
+		err = c.Resolve(&id)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "context value {} resolved outside of scope")
+		ctx, end, err := c.Scope(context.Background())
+		require.NoError(t, err)
+		defer end()
+		scope, _ := di.FromContext(ctx)
+		err = scope.Resolve(&id)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "context_test.go:")
+		require.Contains(t, err.Error(), "di_test.TraceID: context value {} not found")
+	})
+}
@@ -0,0 +1,67 @@
+package di
+
+import (
+	"reflect"
+)
+
+// CopyFunc returns copy of value. The copy must have the same type as value.
+type CopyFunc func(value Value) Value
+
+// Copy returns provide option that makes container hand each consumer a shallow copy of
+// provided value. Pointer to struct, slice and map values are copied, so consumers can't
+// mutate value of each other. Elements of copied values are shared, use di.CopyWith() with
+// deep copy function if it matters. The option has effect on values only.
+//
+//	container, err := di.New(
+//		di.ProvideValue(&Config{Timeout: time.Second}, di.Copy()),
+//	)
+func Copy() ProvideOption {
+	return CopyWith(shallowCopy)
+}
+
+// CopyWith returns provide option that makes container hand each consumer a copy of provided
+// value made by fn. See di.Copy().
+//
+//	container, err := di.New(
+//		di.ProvideValue(config, di.CopyWith(func(value di.Value) di.Value {
+//			return value.(*Config).Clone()
+//		})),
+//	)
+func CopyWith(fn CopyFunc) ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.Copy = fn
+	})
+}
+
+// shallowCopy returns shallow copy of value. Struct and scalar values are copied on assignment,
+// so they returned as is.
+func shallowCopy(value Value) Value {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return value
+		}
+		copied := reflect.New(rv.Type().Elem())
+		copied.Elem().Set(rv.Elem())
+		return copied.Interface()
+	case reflect.Slice:
+		if rv.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		reflect.Copy(copied, rv)
+		return copied.Interface()
+	case reflect.Map:
+		if rv.IsNil() {
+			return value
+		}
+		copied := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), iter.Value())
+		}
+		return copied.Interface()
+	}
+	return value
+}
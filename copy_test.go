@@ -0,0 +1,77 @@
+package di_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestCopy(t *testing.T) {
+	t.Run("each consumer receives shallow copy", func(t *testing.T) {
+		type Config struct {
+			Timeout time.Duration
+		}
+		config := &Config{Timeout: time.Second}
+		c, err := di.New(
+			di.ProvideValue(config, di.Copy()),
+			di.ProvideValue([]string{"a", "b"}, di.Copy()),
+			di.ProvideValue(map[string]int{"a": 1}, di.Copy()),
+		)
+		require.NoError(t, err)
+		var first, second *Config
+		require.NoError(t, c.Resolve(&first))
+		require.NoError(t, c.Resolve(&second))
+		require.Equal(t, config, first)
+		require.True(t, config != first)
+		require.True(t, first != second)
+		first.Timeout = time.Minute
+		require.Equal(t, time.Second, config.Timeout)
+		require.Equal(t, time.Second, second.Timeout)
+		var slice []string
+		require.NoError(t, c.Resolve(&slice))
+		slice[0] = "changed"
+		require.NoError(t, c.Resolve(&slice))
+		require.Equal(t, []string{"a", "b"}, slice)
+		var m map[string]int
+		require.NoError(t, c.Resolve(&m))
+		m["a"] = 2
+		require.NoError(t, c.Resolve(&m))
+		require.Equal(t, map[string]int{"a": 1}, m)
+	})
+
+	t.Run("copy with custom function", func(t *testing.T) {
+		var copies int
+		server := &http.Server{Addr: ":80"}
+		c, err := di.New(
+			di.ProvideValue(server, di.As(new(interface{ Close() error })), di.CopyWith(func(value di.Value) di.Value {
+				copies++
+				return &http.Server{Addr: value.(*http.Server).Addr}
+			})),
+		)
+		require.NoError(t, err)
+		var copied *http.Server
+		require.NoError(t, c.Resolve(&copied))
+		require.Equal(t, ":80", copied.Addr)
+		require.True(t, server != copied)
+		var closer interface{ Close() error }
+		require.NoError(t, c.Resolve(&closer))
+		require.Equal(t, 2, copies)
+	})
+
+	t.Run("copy of different type cause error", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}, di.CopyWith(func(value di.Value) di.Value {
+				return http.Server{}
+			})),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "copy of *http.Server has different type")
+	})
+}
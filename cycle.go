@@ -1,7 +1,10 @@
 package di
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 )
 
 const (
@@ -14,30 +17,207 @@ func visit(s schema, node *node, marks map[*node]int) error {
 		return nil
 	}
 	if marks[node] == temporary {
-		return errCycleDetected // todo: improve message
+		return newCycleError(node)
 	}
 	marks[node] = temporary
-	params, err := node.deps(s)
+	lookup := node.lookup(s)
+	params, err := node.deps(lookup)
 	if err != nil {
-		return fmt.Errorf("%s: %s", node, err)
+		return extendPath(&dependencyError{node: node, err: err}, node)
 	}
 	for _, param := range params {
 		if err := visit(s, param, marks); err != nil {
-			return err
+			return extendPath(err, node)
 		}
 	}
-	for _, field := range node.fields() {
-		n, err := s.find(field.rt, field.tags)
+	for _, field := range node.fields(node.rt, s.scope().tagName) {
+		n, err := lookup.find(field.rt, field.tags)
 		if err != nil && field.optional {
 			continue
 		}
 		if err != nil {
-			return fmt.Errorf("%s: %s", node, err)
+			return extendPath(&dependencyError{node: node, err: err}, node)
 		}
 		if err := visit(s, n, marks); err != nil {
-			return err
+			return extendPath(err, node)
+		}
+	}
+	for _, setter := range node.setters {
+		for _, arg := range setter.args {
+			n, err := lookup.find(arg, Tags{})
+			if err != nil {
+				return extendPath(&dependencyError{node: node, err: err}, node)
+			}
+			if err := visit(s, n, marks); err != nil {
+				return extendPath(err, node)
+			}
+		}
+	}
+	// candidates of selection checked even only one of them will be built
+	if cmp, ok := node.compiler.(*selectCompiler); ok {
+		for _, key := range cmp.keys() {
+			if err := visit(s, cmp.candidates[key], marks); err != nil {
+				return extendPath(err, node)
+			}
+		}
+	}
+	// new implementation of shadow checked even it is not a dependency
+	if cmp, ok := node.compiler.(*shadowCompiler); ok {
+		if err := visit(s, cmp.new, marks); err != nil {
+			return extendPath(err, node)
+		}
+	}
+	// deferred target checked only if cycle strategy does not break at it
+	if cmp, ok := node.compiler.(deferredCompiler); ok && !s.scope().breaks(cmp.deferred()) {
+		if err := visit(s, cmp.deferred(), marks); err != nil {
+			return extendPath(err, node)
 		}
 	}
 	marks[node] = permanent
 	return nil
 }
+
+// CycleStrategy decides where dependency cycles break. The strategy consulted on deferred
+// dependencies: targets of di.Lazy[T] and provider functions func() (T, error). Deferred
+// dependency that breaks cycles is not checked on graph verification and its graph checked
+// on first call.
+type CycleStrategy interface {
+	// Break reports whether deferred dependency on target breaks dependency cycles.
+	Break(target NodeInfo) bool
+}
+
+// CycleStrategyFunc is an adapter to use function as CycleStrategy.
+type CycleStrategyFunc func(target NodeInfo) bool
+
+// Break calls f(target).
+func (f CycleStrategyFunc) Break(target NodeInfo) bool {
+	return f(target)
+}
+
+// CycleError returns strategy that never breaks dependency cycles. Cycles through di.Lazy[T]
+// cause ErrCycleDetected as any other cycles, and targets of di.Lazy[T] checked together with
+// its consumers. It suits codebases that have no cycles by design.
+func CycleError() CycleStrategy {
+	return CycleStrategyFunc(func(target NodeInfo) bool {
+		return false
+	})
+}
+
+// CycleBreakLazy returns strategy that breaks dependency cycles at each di.Lazy[T] and provider
+// function. It is a default strategy.
+func CycleBreakLazy() CycleStrategy {
+	return CycleStrategyFunc(func(target NodeInfo) bool {
+		return true
+	})
+}
+
+// CycleBreakInterface returns strategy that breaks dependency cycles only at di.Lazy[T] of
+// interface type T. Such dependency usually wrapped into proxy that implements the interface,
+// so cycle through concrete types still causes ErrCycleDetected.
+//
+//	type repositoryProxy struct {
+//		lazy di.Lazy[Repository]
+//	}
+//
+//	func (p repositoryProxy) Find(id int) (*User, error) {
+//		repository, err := p.lazy.Get()
+//		if err != nil {
+//			return nil, err
+//		}
+//		return repository.Find(id)
+//	}
+func CycleBreakInterface() CycleStrategy {
+	return CycleStrategyFunc(func(target NodeInfo) bool {
+		return target.Type.Kind() == reflect.Interface
+	})
+}
+
+// WithCycleStrategy returns container option that specifies where dependency cycles break.
+// Child containers inherit strategy of container. By default, di.CycleBreakLazy() used.
+//
+//	container, err := di.New(
+//		di.WithCycleStrategy(di.CycleError()),
+//		di.Provide(NewUserService),
+//		di.Provide(NewOrderService),
+//	)
+func WithCycleStrategy(strategy CycleStrategy) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.schema.cycles = strategy
+		})
+	})
+}
+
+// breaks checks that deferred dependency on target breaks dependency cycles.
+func (s *defaultSchema) breaks(target *node) bool {
+	if s.cycles == nil {
+		return true
+	}
+	return s.cycles.Break(target.info())
+}
+
+// deferredCompiler is a compiler of node which target built on demand.
+type deferredCompiler interface {
+	// deferred returns target node.
+	deferred() *node
+}
+
+// cycleError is a dependency cycle error with path of nodes from the first node of cycle to
+// itself.
+type cycleError struct {
+	path []*node
+	// closed is true when path reaches the first node of cycle
+	closed bool
+}
+
+// newCycleError creates cycle error that starts from node n.
+func newCycleError(n *node) *cycleError {
+	return &cycleError{
+		path: []*node{n},
+	}
+}
+
+// extendPath prepends node to path of resolve error or not closed cycle error.
+func extendPath(err error, n *node) error {
+	var resolve *ResolveError
+	if errors.As(err, &resolve) {
+		resolve.Path = append([]reflect.Type{n.rt}, resolve.Path...)
+	}
+	var cycle *cycleError
+	if !errors.As(err, &cycle) || cycle.closed {
+		return err
+	}
+	cycle.path = append([]*node{n}, cycle.path...)
+	cycle.closed = n == cycle.path[len(cycle.path)-1]
+	return err
+}
+
+func (e *cycleError) Error() string {
+	var path []string
+	for i, n := range e.path {
+		if source := n.frame.source(); source != "" && i != len(e.path)-1 {
+			path = append(path, fmt.Sprintf("%s (%s)", n, source))
+			continue
+		}
+		path = append(path, n.String())
+	}
+	return fmt.Sprintf("%s: %s", ErrCycleDetected, strings.Join(path, " -> "))
+}
+
+func (e *cycleError) Is(target error) bool {
+	return target == ErrCycleDetected
+}
+
+// dependencyError is an error of node dependency lookup.
+type dependencyError struct {
+	node *node
+	err  error
+}
+
+func (e *dependencyError) Error() string {
+	return fmt.Sprintf("%s: %s", e.node, e.err)
+}
+
+func (e *dependencyError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,103 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestWithCycleStrategy(t *testing.T) {
+	type Service struct{ client *http.Client }
+	type Doer interface {
+		Do(r *http.Request) (*http.Response, error)
+	}
+	lazyService := func(lazy di.Lazy[*Service]) *http.Client { return &http.Client{} }
+	newService := func(client *http.Client) *Service { return &Service{client: client} }
+	resolve := func(c *di.Container) error {
+		var service *Service
+		return c.Resolve(&service)
+	}
+
+	t.Run("lazy breaks cycle by default", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(lazyService),
+			di.Provide(newService),
+		)
+		require.NoError(t, err)
+		require.NoError(t, resolve(c))
+	})
+
+	t.Run("cycle error strategy detects cycle through lazy", func(t *testing.T) {
+		c, err := di.New(
+			di.WithCycleStrategy(di.CycleError()),
+			di.Provide(lazyService),
+			di.Provide(newService),
+		)
+		require.NoError(t, err)
+		err = resolve(c)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, di.ErrCycleDetected))
+	})
+
+	t.Run("cycle error strategy checks lazy target", func(t *testing.T) {
+		c, err := di.New(
+			di.WithCycleStrategy(di.CycleError()),
+			di.Provide(func(lazy di.Lazy[*Service]) *http.Client { return &http.Client{} }),
+		)
+		require.NoError(t, err)
+		var client *http.Client
+		err = c.Resolve(&client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Service not exists in the container")
+	})
+
+	t.Run("interface strategy breaks cycle only at interface", func(t *testing.T) {
+		c, err := di.New(
+			di.WithCycleStrategy(di.CycleBreakInterface()),
+			di.Provide(lazyService),
+			di.Provide(newService),
+		)
+		require.NoError(t, err)
+		require.True(t, errors.Is(resolve(c), di.ErrCycleDetected))
+		c, err = di.New(
+			di.WithCycleStrategy(di.CycleBreakInterface()),
+			di.Provide(func(lazy di.Lazy[Doer]) *Service { return &Service{} }),
+			di.Provide(func(service *Service) *http.Client { return &http.Client{} }, di.As(new(Doer))),
+		)
+		require.NoError(t, err)
+		require.NoError(t, resolve(c))
+	})
+
+	t.Run("child container inherits strategy", func(t *testing.T) {
+		c, err := di.New(
+			di.WithCycleStrategy(di.CycleError()),
+		)
+		require.NoError(t, err)
+		child, err := c.NewChild(
+			di.Provide(lazyService),
+			di.Provide(newService),
+		)
+		require.NoError(t, err)
+		require.True(t, errors.Is(resolve(child), di.ErrCycleDetected))
+	})
+
+	t.Run("custom strategy", func(t *testing.T) {
+		var targets []di.NodeInfo
+		c, err := di.New(
+			di.WithCycleStrategy(di.CycleStrategyFunc(func(target di.NodeInfo) bool {
+				targets = append(targets, target)
+				return true
+			})),
+			di.Provide(lazyService),
+			di.Provide(newService),
+		)
+		require.NoError(t, err)
+		require.NoError(t, resolve(c))
+		require.Len(t, targets, 1)
+		require.Equal(t, "*di_test.Service", targets[0].Type.String())
+	})
+}
@@ -0,0 +1,134 @@
+// Package diconsumer provides message consumer registration. Modules provide diconsumer.Consumer
+// values and dispatcher starts them with transport provided by application.
+//
+//	c, err := di.New(
+//		di.Provide(NewKafkaTransport, di.As(new(diconsumer.Transport))),
+//		di.ProvideValue(diconsumer.Consumer{Topic: "orders", Handler: handleOrder, Concurrency: 4}),
+//		diconsumer.Provide(),
+//	)
+//	if err != nil {
+//		// handle error
+//	}
+//	defer c.Cleanup() // stops dispatcher
+//	err = c.Invoke(func(d *diconsumer.Dispatcher) error {
+//		return d.Start(ctx)
+//	})
+package diconsumer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/goava/di"
+)
+
+// Message is a message received from transport.
+type Message struct {
+	Topic   string
+	Payload []byte
+}
+
+// Handler handles message of topic.
+type Handler func(ctx context.Context, msg Message) error
+
+// Consumer is a group member that describes handler of topic. Concurrency is a count of
+// goroutines that handle topic messages, one by default.
+type Consumer struct {
+	Topic       string
+	Handler     Handler
+	Concurrency int
+}
+
+// Transport subscribes on topics. The message channel must be closed when ctx is done.
+type Transport interface {
+	Subscribe(ctx context.Context, topic string) (<-chan Message, error)
+}
+
+// ErrorHandler handles errors of consumer handlers. Errors ignored by default.
+type ErrorHandler func(consumer Consumer, msg Message, err error)
+
+// Dispatcher starts consumers.
+type Dispatcher struct {
+	transport Transport
+	consumers []Consumer
+	onError   ErrorHandler
+	mu        sync.Mutex
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// dispatcherParams is a parameters of dispatcher constructor.
+type dispatcherParams struct {
+	di.Inject
+
+	Transport Transport
+	Consumers []Consumer   `di:"optional"`
+	OnError   ErrorHandler `di:"optional"`
+}
+
+// Provide returns container option that provides *Dispatcher. The dispatcher will be stopped on
+// container cleanup.
+func Provide() di.Option {
+	return di.Provide(newDispatcher)
+}
+
+// newDispatcher creates dispatcher.
+func newDispatcher(params dispatcherParams) (*Dispatcher, func()) {
+	d := &Dispatcher{
+		transport: params.Transport,
+		consumers: params.Consumers,
+		onError:   params.OnError,
+	}
+	return d, d.Stop
+}
+
+// Start subscribes all consumers and runs their handlers.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel != nil {
+		return fmt.Errorf("diconsumer: dispatcher already started")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	for _, consumer := range d.consumers {
+		messages, err := d.transport.Subscribe(ctx, consumer.Topic)
+		if err != nil {
+			cancel()
+			d.wg.Wait()
+			return fmt.Errorf("diconsumer: subscribe %s: %w", consumer.Topic, err)
+		}
+		concurrency := consumer.Concurrency
+		if concurrency < 1 {
+			concurrency = 1
+		}
+		for i := 0; i < concurrency; i++ {
+			d.wg.Add(1)
+			go d.consume(ctx, consumer, messages)
+		}
+	}
+	d.cancel = cancel
+	return nil
+}
+
+// Stop stops consumers and waits their handlers.
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel == nil {
+		return
+	}
+	d.cancel()
+	d.wg.Wait()
+	d.cancel = nil
+}
+
+// consume handles messages until channel closed.
+func (d *Dispatcher) consume(ctx context.Context, consumer Consumer, messages <-chan Message) {
+	defer d.wg.Done()
+	for msg := range messages {
+		if err := consumer.Handler(ctx, msg); err != nil && d.onError != nil {
+			d.onError(consumer, msg, err)
+		}
+	}
+}
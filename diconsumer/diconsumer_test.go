@@ -0,0 +1,108 @@
+package diconsumer_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+	"github.com/goava/di/diconsumer"
+)
+
+// chanTransport sends predefined messages and closes channel when context done.
+type chanTransport struct {
+	messages map[string][]diconsumer.Message
+}
+
+func (t *chanTransport) Subscribe(ctx context.Context, topic string) (<-chan diconsumer.Message, error) {
+	if _, ok := t.messages[topic]; !ok {
+		return nil, errors.New("unknown topic")
+	}
+	ch := make(chan diconsumer.Message)
+	go func() {
+		defer close(ch)
+		for _, msg := range t.messages[topic] {
+			select {
+			case ch <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return ch, nil
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Run("consumers handle messages", func(t *testing.T) {
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		wg.Add(3)
+		var handled []string
+		handler := func(ctx context.Context, msg diconsumer.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, string(msg.Payload))
+			wg.Done()
+			return nil
+		}
+		c, err := di.New(
+			di.ProvideValue(&chanTransport{messages: map[string][]diconsumer.Message{
+				"orders": {{Topic: "orders", Payload: []byte("1")}, {Topic: "orders", Payload: []byte("2")}},
+				"users":  {{Topic: "users", Payload: []byte("3")}},
+			}}, di.As(new(diconsumer.Transport))),
+			di.ProvideValue(diconsumer.Consumer{Topic: "orders", Handler: handler, Concurrency: 2}),
+			di.ProvideValue(diconsumer.Consumer{Topic: "users", Handler: handler}),
+			diconsumer.Provide(),
+		)
+		require.NoError(t, err)
+		require.NoError(t, c.Invoke(func(d *diconsumer.Dispatcher) error {
+			return d.Start(context.Background())
+		}))
+		wg.Wait()
+		c.Cleanup()
+		require.ElementsMatch(t, []string{"1", "2", "3"}, handled)
+	})
+
+	t.Run("handler errors passed to error handler", func(t *testing.T) {
+		var handled error
+		done := make(chan struct{})
+		myErr := errors.New("my error")
+		c, err := di.New(
+			di.ProvideValue(&chanTransport{messages: map[string][]diconsumer.Message{
+				"orders": {{Topic: "orders"}},
+			}}, di.As(new(diconsumer.Transport))),
+			di.ProvideValue(diconsumer.Consumer{Topic: "orders", Handler: func(ctx context.Context, msg diconsumer.Message) error {
+				return myErr
+			}}),
+			di.ProvideValue(diconsumer.ErrorHandler(func(consumer diconsumer.Consumer, msg diconsumer.Message, err error) {
+				handled = err
+				close(done)
+			})),
+			diconsumer.Provide(),
+		)
+		require.NoError(t, err)
+		require.NoError(t, c.Invoke(func(d *diconsumer.Dispatcher) error {
+			return d.Start(context.Background())
+		}))
+		<-done
+		c.Cleanup()
+		require.Equal(t, myErr, handled)
+	})
+
+	t.Run("subscribe error", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&chanTransport{}, di.As(new(diconsumer.Transport))),
+			di.ProvideValue(diconsumer.Consumer{Topic: "orders"}),
+			diconsumer.Provide(),
+		)
+		require.NoError(t, err)
+		err = c.Invoke(func(d *diconsumer.Dispatcher) error {
+			return d.Start(context.Background())
+		})
+		require.EqualError(t, err, "diconsumer: subscribe orders: unknown topic")
+	})
+}
@@ -0,0 +1,75 @@
+// Package dihttp provides helpers to contribute http routes from different modules and to serve
+// requests in request scopes.
+//
+// Modules provide dihttp.Route values and application mounts them on router:
+//
+//	c, err := di.New(
+//		di.Provide(http.NewServeMux),
+//		di.ProvideValue(dihttp.Route{Pattern: "/orders", Handler: orders}),
+//		di.ProvideValue(dihttp.Route{Pattern: "/users", Handler: users}),
+//		dihttp.Mount(),
+//	)
+package dihttp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/goava/di"
+)
+
+// Route is a group member that describes handler of pattern.
+type Route struct {
+	Pattern string
+	Handler http.Handler
+}
+
+// Router is an interface of routers that can register handlers by pattern. The *http.ServeMux
+// implements it. Routers of other libraries can be adapted to it.
+type Router interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// mountParams is a parameters of mount invocation.
+type mountParams struct {
+	di.Inject
+
+	Mux    *http.ServeMux `di:"optional"`
+	Router Router         `di:"optional"`
+	Routes []Route        `di:"optional"`
+}
+
+// Mount returns container option that mounts all provided routes on router on container
+// creation. If container has a Router definition it will be used, otherwise *http.ServeMux.
+func Mount() di.Option {
+	return di.Invoke(func(params mountParams) error {
+		var router Router
+		switch {
+		case params.Router != nil:
+			router = params.Router
+		case params.Mux != nil:
+			router = params.Mux
+		default:
+			return fmt.Errorf("dihttp: router not found, provide *http.ServeMux or dihttp.Router")
+		}
+		for _, route := range params.Routes {
+			router.Handle(route.Pattern, route.Handler)
+		}
+		return nil
+	})
+}
+
+// Scope returns middleware that creates request scope for each request. The scope is a child
+// container that provides *http.Request, it available in handlers by di.FromContext(). The
+// di.Scoped definitions are instantiated once per request and cleaned up when request served.
+func Scope(c *di.Container, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, end, err := c.Scope(r.Context(), di.ProvideValue(r))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer end()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
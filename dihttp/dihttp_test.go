@@ -0,0 +1,82 @@
+package dihttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+	"github.com/goava/di/dihttp"
+)
+
+type router struct {
+	patterns []string
+}
+
+func (r *router) Handle(pattern string, handler http.Handler) {
+	r.patterns = append(r.patterns, pattern)
+}
+
+func TestMount(t *testing.T) {
+	t.Run("mount routes on serve mux", func(t *testing.T) {
+		mux := http.NewServeMux()
+		_, err := di.New(
+			di.ProvideValue(mux),
+			di.ProvideValue(dihttp.Route{Pattern: "/ok", Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			})}),
+			dihttp.Mount(),
+		)
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ok", nil))
+		require.Equal(t, http.StatusTeapot, rec.Code)
+	})
+
+	t.Run("mount routes on router", func(t *testing.T) {
+		r := &router{}
+		_, err := di.New(
+			di.ProvideValue(r, di.As(new(dihttp.Router))),
+			di.ProvideValue(dihttp.Route{Pattern: "/first", Handler: http.NotFoundHandler()}),
+			di.ProvideValue(dihttp.Route{Pattern: "/second", Handler: http.NotFoundHandler()}),
+			dihttp.Mount(),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"/first", "/second"}, r.patterns)
+	})
+
+	t.Run("mount without router cause error", func(t *testing.T) {
+		_, err := di.New(
+			dihttp.Mount(),
+		)
+		require.EqualError(t, err, "dihttp: router not found, provide *http.ServeMux or dihttp.Router")
+	})
+}
+
+func TestScope(t *testing.T) {
+	t.Run("handler resolves request scoped instances", func(t *testing.T) {
+		type RequestID string
+		cleaned := 0
+		c, err := di.New(
+			di.Provide(func(r *http.Request) (RequestID, func()) {
+				return RequestID(r.URL.Path), func() { cleaned++ }
+			}, di.WithLifetime(di.Scoped)),
+		)
+		require.NoError(t, err)
+		handler := dihttp.Scope(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope, ok := di.FromContext(r.Context())
+			require.True(t, ok)
+			var id RequestID
+			require.NoError(t, scope.Resolve(&id))
+			_, _ = w.Write([]byte(id))
+		}))
+		for _, path := range []string{"/first", "/second"} {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+			require.Equal(t, path, rec.Body.String())
+		}
+		require.Equal(t, 2, cleaned)
+	})
+}
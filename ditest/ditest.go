@@ -0,0 +1,96 @@
+// Package ditest provides deterministic implementations of di standard definitions for tests.
+//
+//	clock := ditest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+//	c, err := di.New(
+//		ditest.ProvideClock(clock),
+//		ditest.ProvideRand(ditest.NewRand(42)),
+//	)
+//	clock.Advance(time.Minute)
+package ditest
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/goava/di"
+)
+
+// Clock is a manually controlled di.Clock.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock creates clock that stopped at now.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns current clock time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since returns clock time elapsed since t.
+func (c *Clock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Advance moves clock forward by d.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set sets clock time.
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// ProvideClock returns container option that provides clock as di.Clock.
+func ProvideClock(clock *Clock) di.Option {
+	return di.ProvideValue(clock, di.As(new(di.Clock)))
+}
+
+// Rand is a di.Rand with fixed seed.
+type Rand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// NewRand creates rand with seed. Rands with the same seed return the same sequence.
+func NewRand(seed int64) *Rand {
+	return &Rand{r: rand.New(rand.NewSource(seed))}
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer.
+func (r *Rand) Int63() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.Int63()
+}
+
+// Intn returns a non-negative pseudo-random number in [0,n).
+func (r *Rand) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.Intn(n)
+}
+
+// Float64 returns a pseudo-random number in [0.0,1.0).
+func (r *Rand) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.Float64()
+}
+
+// ProvideRand returns container option that provides r as di.Rand.
+func ProvideRand(r *Rand) di.Option {
+	return di.ProvideValue(r, di.As(new(di.Rand)))
+}
@@ -0,0 +1,39 @@
+package ditest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+	"github.com/goava/di/ditest"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c, err := di.New(
+		ditest.ProvideClock(ditest.NewClock(start)),
+	)
+	require.NoError(t, err)
+	var clock di.Clock
+	require.NoError(t, c.Resolve(&clock))
+	require.Equal(t, start, clock.Now())
+	var fake *ditest.Clock
+	require.NoError(t, c.Resolve(&fake))
+	fake.Advance(time.Minute)
+	require.Equal(t, time.Minute, clock.Since(start))
+}
+
+func TestRand(t *testing.T) {
+	c, err := di.New(
+		ditest.ProvideRand(ditest.NewRand(42)),
+	)
+	require.NoError(t, err)
+	var r di.Rand
+	require.NoError(t, c.Resolve(&r))
+	expected := ditest.NewRand(42)
+	require.Equal(t, expected.Int63(), r.Int63())
+	require.Equal(t, expected.Intn(100), r.Intn(100))
+	require.Equal(t, expected.Float64(), r.Float64())
+}
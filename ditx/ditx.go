@@ -0,0 +1,97 @@
+// Package ditx provides transaction scopes. Each transaction scope is a child container of the
+// application container where *sql.Tx and transaction-scoped definitions are provided.
+//
+//	c, err := di.New(
+//		di.Provide(NewDB),                   // provides *sql.DB
+//		ditx.Provide(NewOrderRepository),    // depends on *sql.Tx
+//	)
+//	if err != nil {
+//		// handle error
+//	}
+//	err = ditx.Run(ctx, c, func(orders *OrderRepository) error {
+//		return orders.Create(order)
+//	})
+package ditx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/goava/di"
+)
+
+// definition is a transaction-scoped constructor with options.
+type definition struct {
+	constructor di.Constructor
+	options     []di.ProvideOption
+}
+
+// Provide returns container option that registers transaction-scoped constructor. The
+// constructor will be provided in each transaction scope and can depend on *sql.Tx.
+func Provide(constructor di.Constructor, options ...di.ProvideOption) di.Option {
+	return di.ProvideValue(definition{
+		constructor: constructor,
+		options:     options,
+	})
+}
+
+// Run begins transaction with *sql.DB resolved from container c, creates transaction scope and
+// invokes fn in it. The transaction is committed if fn succeeds and rolled back otherwise.
+// Cleanups of transaction scope run after transaction completion.
+func Run(ctx context.Context, c *di.Container, fn di.Invocation, opts ...*sql.TxOptions) (err error) {
+	var db *sql.DB
+	if err := c.Resolve(&db); err != nil {
+		return err
+	}
+	var txOpts *sql.TxOptions
+	if len(opts) > 0 {
+		txOpts = opts[0]
+	}
+	tx, err := db.BeginTx(ctx, txOpts)
+	if err != nil {
+		return fmt.Errorf("ditx: begin transaction: %w", err)
+	}
+	scope, err := newScope(c, tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	defer scope.Cleanup()
+	if err := scope.Invoke(fn); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("ditx: rollback: %s: %w", rbErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ditx: commit: %w", err)
+	}
+	return nil
+}
+
+// newScope creates transaction scope container. The scope is a child of parent, so types of
+// parent container itself, e.g. *di.Container, resolved from scope without ambiguity.
+func newScope(parent *di.Container, tx *sql.Tx) (*di.Container, error) {
+	scope, err := parent.NewChild(
+		di.ProvideValue(tx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var definitions []definition
+	if has, err := parent.Has(&definitions); err != nil {
+		return nil, err
+	} else if !has {
+		return scope, nil
+	}
+	if err := parent.Resolve(&definitions); err != nil {
+		return nil, err
+	}
+	for _, def := range definitions {
+		if err := scope.Provide(def.constructor, def.options...); err != nil {
+			return nil, err
+		}
+	}
+	return scope, nil
+}
@@ -0,0 +1,109 @@
+package ditx_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+	"github.com/goava/di/ditx"
+)
+
+// fakeDriver is a database driver that only records transaction completions.
+type fakeDriver struct {
+	commits   int
+	rollbacks int
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) { return &fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return &fakeTx{c.d}, nil }
+
+type fakeTx struct{ d *fakeDriver }
+
+func (t *fakeTx) Commit() error   { t.d.commits++; return nil }
+func (t *fakeTx) Rollback() error { t.d.rollbacks++; return nil }
+
+type Repository struct {
+	tx *sql.Tx
+}
+
+var fake = &fakeDriver{}
+
+func init() {
+	sql.Register("ditx_fake", fake)
+}
+
+func TestRun(t *testing.T) {
+	newContainer := func(t *testing.T, cleanups *int) *di.Container {
+		db, err := sql.Open("ditx_fake", "")
+		require.NoError(t, err)
+		c, err := di.New(
+			di.ProvideValue(db),
+			ditx.Provide(func(tx *sql.Tx) (*Repository, func()) {
+				return &Repository{tx: tx}, func() { *cleanups++ }
+			}),
+		)
+		require.NoError(t, err)
+		return c
+	}
+
+	t.Run("commit on success", func(t *testing.T) {
+		var cleanups int
+		c := newContainer(t, &cleanups)
+		var repositories []*Repository
+		for i := 0; i < 2; i++ {
+			before := fake.commits
+			err := ditx.Run(context.Background(), c, func(repo *Repository) {
+				repositories = append(repositories, repo)
+			})
+			require.NoError(t, err)
+			require.Equal(t, before+1, fake.commits)
+		}
+		require.Len(t, repositories, 2)
+		require.False(t, repositories[0].tx == repositories[1].tx)
+		require.Equal(t, 2, cleanups)
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		var cleanups int
+		c := newContainer(t, &cleanups)
+		before := fake.rollbacks
+		myErr := errors.New("my error")
+		err := ditx.Run(context.Background(), c, func(repo *Repository) error {
+			return myErr
+		})
+		require.True(t, errors.Is(err, myErr))
+		require.Equal(t, before+1, fake.rollbacks)
+		require.Equal(t, 1, cleanups)
+	})
+
+	t.Run("run without scoped definitions", func(t *testing.T) {
+		db, err := sql.Open("ditx_fake", "")
+		require.NoError(t, err)
+		c, err := di.New(di.ProvideValue(db))
+		require.NoError(t, err)
+		require.NoError(t, ditx.Run(context.Background(), c, func(tx *sql.Tx) {}))
+	})
+
+	t.Run("scope resolves itself as container", func(t *testing.T) {
+		var cleanups int
+		c := newContainer(t, &cleanups)
+		err := ditx.Run(context.Background(), c, func(scope *di.Container, info di.BuildInfo) error {
+			var repo *Repository
+			return scope.Resolve(&repo)
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, cleanups)
+	})
+}
@@ -0,0 +1,116 @@
+// Package diwire converts google/wire style provider sets into container options. It helps to
+// migrate parts of application from wire generated code to runtime dependency injection. Replace
+// wire.NewSet, wire.Bind and wire.Value calls with functions of this package and convert sets:
+//
+//	var RepositorySet = diwire.NewSet(
+//		NewDB,
+//		NewUserRepository,
+//		diwire.Bind(new(UserStore), new(*UserRepository)),
+//	)
+//
+//	var ServerSet = diwire.NewSet(RepositorySet, NewServer, diwire.Value(Config{Port: 8080}))
+//
+//	option, err := diwire.Convert(ServerSet)
+//	if err != nil {
+//		// handle error
+//	}
+//	c, err := di.New(option)
+package diwire
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/goava/di"
+)
+
+// ProviderSet is a set of providers: constructors, bindings, values and other sets.
+type ProviderSet []interface{}
+
+// NewSet creates provider set. Providers can be constructors, bindings, values, provider sets
+// and slices of providers.
+func NewSet(providers ...interface{}) ProviderSet {
+	return providers
+}
+
+// Binding binds interface to type provided by constructor of the same set.
+type Binding struct {
+	iface interface{}
+	to    reflect.Type
+}
+
+// Bind creates binding of interface to type, like wire.Bind(new(Fooer), new(*MyFooer)).
+func Bind(iface, to interface{}) Binding {
+	return Binding{
+		iface: iface,
+		to:    reflect.TypeOf(to).Elem(),
+	}
+}
+
+// ValueProvider is a provider of value as is.
+type ValueProvider struct {
+	value interface{}
+}
+
+// Value creates provider of value, like wire.Value(Config{}).
+func Value(value interface{}) ValueProvider {
+	return ValueProvider{
+		value: value,
+	}
+}
+
+// Convert converts provider sets into container option. It returns error if provider is not
+// supported or binding refers to type that no constructor of sets provides.
+func Convert(sets ...interface{}) (di.Option, error) {
+	var (
+		constructors []interface{}
+		bindings     []Binding
+		options      []di.Option
+	)
+	var flatten func(providers []interface{}) error
+	flatten = func(providers []interface{}) error {
+		for _, provider := range providers {
+			switch p := provider.(type) {
+			case ProviderSet:
+				if err := flatten(p); err != nil {
+					return err
+				}
+			case []interface{}:
+				if err := flatten(p); err != nil {
+					return err
+				}
+			case Binding:
+				bindings = append(bindings, p)
+			case ValueProvider:
+				options = append(options, di.ProvideValue(p.value))
+			default:
+				if reflect.TypeOf(provider) == nil || reflect.TypeOf(provider).Kind() != reflect.Func {
+					return fmt.Errorf("diwire: unsupported provider %T", provider)
+				}
+				constructors = append(constructors, provider)
+			}
+		}
+		return nil
+	}
+	if err := flatten(sets); err != nil {
+		return nil, err
+	}
+	interfaces := make([][]di.ProvideOption, len(constructors))
+	for _, binding := range bindings {
+		found := false
+		for i, constructor := range constructors {
+			rt := reflect.TypeOf(constructor)
+			if rt.NumOut() > 0 && rt.Out(0) == binding.to {
+				interfaces[i] = append(interfaces[i], di.As(binding.iface))
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("diwire: binding to %s has no constructor", binding.to)
+		}
+	}
+	for i, constructor := range constructors {
+		options = append(options, di.Provide(constructor, interfaces[i]...))
+	}
+	return di.Options(options...), nil
+}
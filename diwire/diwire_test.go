@@ -0,0 +1,49 @@
+package diwire_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+	"github.com/goava/di/diwire"
+)
+
+func TestConvert(t *testing.T) {
+	t.Run("nested sets with bindings and values", func(t *testing.T) {
+		handlers := diwire.NewSet(
+			http.NewServeMux,
+			diwire.Bind(new(http.Handler), new(*http.ServeMux)),
+		)
+		set := diwire.NewSet(
+			handlers,
+			[]interface{}{
+				func(handler http.Handler, addr string) *http.Server {
+					return &http.Server{Addr: addr, Handler: handler}
+				},
+			},
+			diwire.Value(":8080"),
+		)
+		option, err := diwire.Convert(set)
+		require.NoError(t, err)
+		c, err := di.New(option)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, ":8080", server.Addr)
+		require.IsType(t, &http.ServeMux{}, server.Handler)
+	})
+
+	t.Run("binding without constructor", func(t *testing.T) {
+		_, err := diwire.Convert(diwire.NewSet(
+			diwire.Bind(new(http.Handler), new(*http.ServeMux)),
+		))
+		require.EqualError(t, err, "diwire: binding to *http.ServeMux has no constructor")
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		_, err := diwire.Convert(diwire.NewSet(1))
+		require.EqualError(t, err, "diwire: unsupported provider int")
+	})
+}
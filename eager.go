@@ -0,0 +1,46 @@
+package di
+
+import (
+	"fmt"
+)
+
+// Eager returns provide option that constructs provided type on provide instead of first
+// resolve. Constructor errors surface on container creation.
+//
+//	container, err := di.New(
+//		di.Provide(NewDatabase, di.Eager()),
+//	)
+func Eager() ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.Eager = true
+	})
+}
+
+// EagerInit returns container option that constructs all singletons on provide, as if each
+// of them provided with di.Eager().
+func EagerInit() Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.eager = true
+		})
+	})
+}
+
+// initEager constructs nodes that wait eager initialization in provide order.
+func (c *Container) initEager() error {
+	pending := c.pending
+	c.pending = nil
+	c.schema.eager = true
+	defer func() {
+		c.schema.eager = false
+	}()
+	for _, n := range pending {
+		if err := c.schema.prepare(n); err != nil {
+			return fmt.Errorf("%s: %w", n.frame, err)
+		}
+		if _, err := n.Value(c.schema); err != nil {
+			return fmt.Errorf("%s: %s: %w", n.frame, n, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestEager(t *testing.T) {
+	t.Run("eager type constructed on container creation", func(t *testing.T) {
+		var created []string
+		_, err := di.New(
+			di.Provide(func() *http.ServeMux {
+				created = append(created, "mux")
+				return &http.ServeMux{}
+			}),
+			di.Provide(func() *http.Server {
+				created = append(created, "server")
+				return &http.Server{}
+			}, di.Eager()),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"server"}, created)
+	})
+
+	t.Run("eager constructor error surface on container creation", func(t *testing.T) {
+		_, err := di.New(
+			di.Provide(func() (*http.Server, error) {
+				return nil, errors.New("server error")
+			}, di.Eager()),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "eager_test.go:")
+		require.Contains(t, err.Error(), "*http.Server: server error")
+	})
+
+	t.Run("eager type provided into container", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		err = c.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{} }, di.Eager())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type *http.ServeMux not exists in the container")
+	})
+
+	t.Run("eager value provided into container", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		var decorated bool
+		err = c.ProvideValue(&http.Server{}, di.Eager(), di.Decorate(func(value di.Value) error {
+			decorated = true
+			return nil
+		}))
+		require.NoError(t, err)
+		require.True(t, decorated)
+		err = c.ProvideValue(&http.Client{}, di.Eager(), di.Decorate(func(value di.Value) error {
+			return errors.New("client error")
+		}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "eager_test.go:")
+		require.Contains(t, err.Error(), "*http.Client: client error")
+	})
+}
+
+func TestEagerInit(t *testing.T) {
+	t.Run("all singletons constructed on container creation", func(t *testing.T) {
+		var created []string
+		_, err := di.New(
+			di.EagerInit(),
+			di.Provide(func() *http.ServeMux {
+				created = append(created, "mux")
+				return &http.ServeMux{}
+			}),
+			di.Provide(func(mux *http.ServeMux) *http.Server {
+				created = append(created, "server")
+				return &http.Server{}
+			}),
+			di.Provide(func() *http.Client {
+				created = append(created, "client")
+				return &http.Client{}
+			}, di.WithLifetime(di.Transient)),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"mux", "server"}, created)
+	})
+}
@@ -3,24 +3,123 @@ package di
 import (
 	"errors"
 	"fmt"
+	"reflect"
 )
 
 var (
 	// ErrTypeNotExists causes when type not found in container.
 	ErrTypeNotExists = errors.New("not exists in the container")
+	// ErrAmbiguousType causes when multiple definitions match one dependency.
+	ErrAmbiguousType = errors.New("multiple definitions")
+	// ErrCycleDetected causes when dependency graph contains cycle.
+	ErrCycleDetected = errors.New("cycle detected")
+	// ErrInvalidConstructor causes when constructor signature is not supported.
+	ErrInvalidConstructor = errors.New("invalid constructor signature")
+	// ErrInvalidInvocation causes when invocation signature is not supported.
+	ErrInvalidInvocation = errors.New("invalid invocation signature")
+	// ErrScopeClosed causes when closed scope handle used.
+	ErrScopeClosed = errors.New("scope closed")
+	// ErrNotAuthorized causes when authorization policy denies dependency.
+	ErrNotAuthorized = errors.New("not authorized")
 )
 
 var (
-	errInvalidInvocationSignature = errors.New("invalid invocation signature")
-	errCycleDetected              = errors.New("cycle detected")
-	errFieldsNotSupported         = errors.New("fields not supported")
+	errFieldsNotSupported = errors.New("fields not supported")
 )
 
+// ResolveError is an error of type that not exists or ambiguous. It wraps ErrTypeNotExists or
+// ErrAmbiguousType.
+//
+//	var resolveErr *di.ResolveError
+//	if errors.As(err, &resolveErr) {
+//		log.Printf("%s not resolved, path: %s", resolveErr.Type, resolveErr.Path)
+//	}
+type ResolveError struct {
+	// Type is a type that failed to resolve.
+	Type reflect.Type
+	// Tags is a tags that type requested with.
+	Tags Tags
+	// Path is a dependency path from resolving type to failed type inclusive.
+	Path []reflect.Type
+	err  error
+}
+
+// resolveError creates resolve error of type t with query q.
+func resolveError(t reflect.Type, q query, err error) *ResolveError {
+	return &ResolveError{
+		Type: t,
+		Tags: q.tags,
+		Path: []reflect.Type{t},
+		err:  err,
+	}
+}
+
+func (e *ResolveError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ResolveError) Unwrap() error {
+	return e.err
+}
+
+// ErrorCode is a stable machine-readable code of container error. Unlike error messages codes
+// don't change between versions. See di.Code().
+type ErrorCode string
+
+const (
+	// CodeTypeNotExists is a code of error caused by type that not found in container.
+	CodeTypeNotExists ErrorCode = "DI001"
+	// CodeCycleDetected is a code of dependency cycle error.
+	CodeCycleDetected ErrorCode = "DI002"
+	// CodeAmbiguous is a code of error caused by multiple definitions matching one dependency.
+	CodeAmbiguous ErrorCode = "DI003"
+	// CodeInvalidSignature is a code of invalid constructor or invocation signature error.
+	CodeInvalidSignature ErrorCode = "DI004"
+	// CodeScopeClosed is a code of error caused by usage of closed scope.
+	CodeScopeClosed ErrorCode = "DI005"
+	// CodeNotAuthorized is a code of error caused by authorization policy.
+	CodeNotAuthorized ErrorCode = "DI006"
+	// CodeVerificationFailed is a code of Container.Verify() error.
+	CodeVerificationFailed ErrorCode = "DI007"
+)
+
+// errorCodes is a codes of known errors in order of precedence.
+var errorCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	{ErrNotAuthorized, CodeNotAuthorized},
+	{ErrCycleDetected, CodeCycleDetected},
+	{ErrAmbiguousType, CodeAmbiguous},
+	{ErrTypeNotExists, CodeTypeNotExists},
+	{ErrInvalidInvocation, CodeInvalidSignature},
+	{ErrInvalidConstructor, CodeInvalidSignature},
+	{ErrScopeClosed, CodeScopeClosed},
+}
+
+// Code returns code of container error. It returns empty code if err is not a container error.
+//
+//	if di.Code(err) == di.CodeTypeNotExists {
+//		// handle missing type
+//	}
+func Code(err error) ErrorCode {
+	var verr *VerificationError
+	if errors.As(err, &verr) {
+		return CodeVerificationFailed
+	}
+	for _, known := range errorCodes {
+		if errors.Is(err, known.err) {
+			return known.code
+		}
+	}
+	return ""
+}
+
 // knownError return true if err is library known error.
 func knownError(err error) bool {
 	if errors.Is(err, ErrTypeNotExists) ||
-		errors.Is(err, errInvalidInvocationSignature) ||
-		errors.Is(err, errCycleDetected) ||
+		errors.Is(err, ErrInvalidInvocation) ||
+		errors.Is(err, ErrCycleDetected) ||
 		errors.Is(err, errFieldsNotSupported) {
 		return true
 	}
@@ -28,7 +127,59 @@ func knownError(err error) bool {
 }
 
 func errWithStack(err error) error {
-	return fmt.Errorf("%s: %w", stacktrace(1), err)
+	return fmt.Errorf("%s: %w", stacktrace(1), renderError(err))
+}
+
+// ErrorRenderer renders message of container error with code. It can translate message or
+// add details like links to runbooks.
+type ErrorRenderer func(code ErrorCode, message string) string
+
+var errorRenderer ErrorRenderer
+
+// SetErrorRenderer sets global renderer of container error messages. The renderer called for
+// errors that have code, see di.Code(). Nil renderer resets default messages.
+//
+//	di.SetErrorRenderer(func(code di.ErrorCode, message string) string {
+//		if code == di.CodeCycleDetected {
+//			return message + " (see https://wiki.example.com/runbooks/di-cycles)"
+//		}
+//		return message
+//	})
+func SetErrorRenderer(r ErrorRenderer) {
+	errorRenderer = r
+}
+
+// renderedError is a container error with message of renderer.
+type renderedError struct {
+	err     error
+	message string
+}
+
+func (e *renderedError) Error() string {
+	return e.message
+}
+
+func (e *renderedError) Unwrap() error {
+	return e.err
+}
+
+// renderError renders message of container error by global renderer.
+func renderError(err error) error {
+	if err == nil || errorRenderer == nil {
+		return err
+	}
+	var rendered *renderedError
+	if errors.As(err, &rendered) {
+		return err
+	}
+	code := Code(err)
+	if code == "" {
+		return err
+	}
+	return &renderedError{
+		err:     err,
+		message: errorRenderer(code, err.Error()),
+	}
 }
 
 func bug() {
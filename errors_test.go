@@ -0,0 +1,179 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestCode(t *testing.T) {
+	t.Run("type not exists", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		var server *http.Server
+		require.Equal(t, di.CodeTypeNotExists, di.Code(c.Resolve(&server)))
+	})
+
+	t.Run("dependency not exists", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.Equal(t, di.CodeTypeNotExists, di.Code(c.Resolve(&server)))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func(int32) bool { return true }),
+			di.Provide(func(bool) int32 { return 0 }),
+		)
+		require.NoError(t, err)
+		var b bool
+		require.Equal(t, di.CodeCycleDetected, di.Code(c.Resolve(&b)))
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler))),
+			di.Provide(func() http.HandlerFunc { return nil }, di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		require.Equal(t, di.CodeAmbiguous, di.Code(c.Resolve(&handler)))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		_, err := di.New(di.Provide(1))
+		require.Equal(t, di.CodeInvalidSignature, di.Code(err))
+		c, err := di.New()
+		require.NoError(t, err)
+		require.Equal(t, di.CodeInvalidSignature, di.Code(c.Invoke(1)))
+	})
+
+	t.Run("not authorized", func(t *testing.T) {
+		c, err := di.New(
+			di.Authorize(func(consumer, target di.NodeInfo) error { return errors.New("denied") }),
+			di.Provide(http.NewServeMux),
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.Equal(t, di.CodeNotAuthorized, di.Code(err))
+		require.Contains(t, err.Error(), "*http.Server is not authorized to obtain *http.ServeMux: denied")
+	})
+
+	t.Run("verification failed", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		require.Equal(t, di.CodeVerificationFailed, di.Code(c.Verify()))
+	})
+
+	t.Run("not a container error", func(t *testing.T) {
+		require.Equal(t, di.ErrorCode(""), di.Code(errors.New("error")))
+		require.Equal(t, di.ErrorCode(""), di.Code(nil))
+	})
+}
+
+func TestSetErrorRenderer(t *testing.T) {
+	di.SetErrorRenderer(func(code di.ErrorCode, message string) string {
+		if code == di.CodeCycleDetected {
+			return message + " (see runbook)"
+		}
+		return string(code) + ": " + message
+	})
+	defer di.SetErrorRenderer(nil)
+
+	t.Run("resolve error rendered", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func(int32) bool { return true }),
+			di.Provide(func(bool) int32 { return 0 }),
+		)
+		require.NoError(t, err)
+		var b bool
+		err = c.Resolve(&b)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "errors_test.go:")
+		require.Regexp(t, `cycle detected: .+ -> bool \(see runbook\)$`, err.Error())
+		require.Equal(t, di.CodeCycleDetected, di.Code(err))
+	})
+
+	t.Run("container creation error rendered", func(t *testing.T) {
+		_, err := di.New(di.Provide(1))
+		require.Error(t, err)
+		require.Regexp(t, `^DI004: .+errors_test.go:\d+: invalid constructor signature, got int$`, err.Error())
+	})
+
+	t.Run("errors without code not rendered", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() (*http.Server, error) { return nil, errors.New("failed") }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.Error(t, err)
+		require.Regexp(t, `errors_test.go:\d+: \*http.Server: failed$`, err.Error())
+	})
+}
+
+func TestResolveError(t *testing.T) {
+	t.Run("missing dependency", func(t *testing.T) {
+		type Handler struct {
+			di.Inject
+			Mux *http.ServeMux `di:"name=api"`
+		}
+		c, err := di.New(
+			di.Provide(func(handler *Handler) *http.Server { return &http.Server{} }),
+			di.Provide(func() *Handler { return &Handler{} }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.True(t, errors.Is(err, di.ErrTypeNotExists))
+		var resolveErr *di.ResolveError
+		require.True(t, errors.As(err, &resolveErr))
+		require.Equal(t, reflect.TypeOf(new(http.ServeMux)), resolveErr.Type)
+		require.Equal(t, di.Tags{"name": "api"}, resolveErr.Tags)
+		require.Equal(t, []reflect.Type{
+			reflect.TypeOf(new(http.Server)),
+			reflect.TypeOf(new(Handler)),
+			reflect.TypeOf(new(http.ServeMux)),
+		}, resolveErr.Path)
+	})
+
+	t.Run("ambiguous type", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "public"}),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "private"}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.True(t, errors.Is(err, di.ErrAmbiguousType))
+		var resolveErr *di.ResolveError
+		require.True(t, errors.As(err, &resolveErr))
+		require.Equal(t, reflect.TypeOf(new(http.Server)), resolveErr.Type)
+		require.Equal(t, []reflect.Type{reflect.TypeOf(new(http.Server))}, resolveErr.Path)
+	})
+
+	t.Run("sentinel errors", func(t *testing.T) {
+		_, err := di.New(di.Provide(1))
+		require.True(t, errors.Is(err, di.ErrInvalidConstructor))
+		c, err := di.New(
+			di.Provide(func(int32) bool { return true }),
+			di.Provide(func(bool) int32 { return 0 }),
+		)
+		require.NoError(t, err)
+		require.True(t, errors.Is(c.Invoke(1), di.ErrInvalidInvocation))
+		var b bool
+		require.True(t, errors.Is(c.Resolve(&b), di.ErrCycleDetected))
+	})
+}
@@ -0,0 +1,74 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ExposeFields returns provide option that also provides exported fields of provided struct.
+// Each field provided with name tag that consists of struct type name with lowercase first
+// letter and field name. Fields built from provided struct instance and share its lifetime.
+//
+//	type Config struct {
+//		HTTPPort int
+//	}
+//
+//	type Server struct {
+//		di.Inject
+//		Port int `di:"name=config.HTTPPort"`
+//	}
+//
+//	container, err := di.New(
+//		di.Provide(LoadConfig, di.ExposeFields()),
+//	)
+func ExposeFields() ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.ExposeFields = true
+	})
+}
+
+// exposeFields provides exported fields of struct node.
+func (c *Container) exposeFields(n *node) error {
+	rt := n.rt
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct || rt.Name() == "" {
+		return fmt.Errorf("%s: expose fields requires named struct type", n)
+	}
+	prefix := strings.ToLower(rt.Name()[:1]) + rt.Name()[1:]
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" || f.Type == injectType || f.Type == outType {
+			continue
+		}
+		c.provideField(n, i, Tags{"name": prefix + "." + f.Name})
+	}
+	return nil
+}
+
+// provideField provides field of struct node with tags.
+func (c *Container) provideField(n *node, index int, tags Tags) {
+	rt := n.rt
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	field := &node{
+		compiler: fieldCompiler{
+			source: n,
+			index:  index,
+		},
+		rt:           rt.Field(index).Type,
+		tags:         tags,
+		rv:           new(reflect.Value),
+		frame:        n.frame,
+		dependencies: []TypeRef{{Type: n.rt, Tags: n.tags}},
+		lifetime:     n.lifetime,
+		cache:        n.cache,
+		sensitive:    n.sensitive,
+	}
+	c.schema.register(field)
+	c.checkShadowing(field)
+	c.notifyGroupChange(field.rt, field.tags)
+}
@@ -0,0 +1,62 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+type ExposedConfig struct {
+	HTTPPort int
+	Host     string
+	secret   string
+}
+
+func TestExposeFields(t *testing.T) {
+	t.Run("fields provided with qualified names", func(t *testing.T) {
+		calls := 0
+		c, err := di.New(
+			di.Provide(func() *ExposedConfig {
+				calls++
+				return &ExposedConfig{HTTPPort: 8080, Host: "localhost", secret: "s"}
+			}, di.ExposeFields()),
+		)
+		require.NoError(t, err)
+		type Server struct {
+			di.Inject
+			Port int    `di:"name=exposedConfig.HTTPPort"`
+			Host string `di:"name=exposedConfig.Host"`
+		}
+		var server Server
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, 8080, server.Port)
+		require.Equal(t, "localhost", server.Host)
+		require.Equal(t, 1, calls)
+		var config *ExposedConfig
+		require.NoError(t, c.Resolve(&config))
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("unexported fields not provided", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(ExposedConfig{}, di.ExposeFields()),
+		)
+		require.NoError(t, err)
+		var secret string
+		has, err := c.Has(&secret, di.Tags{"name": "exposedConfig.secret"})
+		require.NoError(t, err)
+		require.False(t, has)
+		require.NoError(t, c.Resolve(&secret, di.Tags{"name": "exposedConfig.Host"}))
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := di.New(
+			di.ProvideValue(1, di.ExposeFields()),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expose_test.go:")
+		require.Contains(t, err.Error(), "int: expose fields requires named struct type")
+	})
+}
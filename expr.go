@@ -0,0 +1,328 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ExprEvaluator evaluates boolean expression of di.WhenExpr() with variables.
+type ExprEvaluator func(expr string, vars map[string]interface{}) (bool, error)
+
+// WhenExpr returns provide option that provides type only when expression evaluates to true.
+// The variables of expression are exported fields of config registered by di.ExprConfig().
+// The default evaluator supports ==, !=, &&, ||, !, parentheses, quoted strings, numbers and
+// booleans. Other evaluator can be registered by di.WithExprEvaluator().
+//
+//	container, err := di.New(
+//		di.ProvideValue(&Config{Env: "prod", Region: "eu"}),
+//		di.ExprConfig(new(*Config)),
+//		di.Provide(NewTracer, di.WhenExpr("env == 'prod' && region != 'cn'")),
+//	)
+func WhenExpr(expr string) ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.condition = expr
+	})
+}
+
+// ExprConfig returns container option that specifies config which exported fields are variables
+// of di.WhenExpr() expressions. The config resolved from container on first evaluation. The
+// variable name is a value of `expr` field tag or a field name with lowercase first letter.
+//
+//	type Config struct {
+//		Env    string
+//		Region string `expr:"region"`
+//	}
+func ExprConfig(target Pointer) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.exprConfig = target
+		})
+	})
+}
+
+// WithExprEvaluator returns container option that replaces default evaluator of di.WhenExpr()
+// expressions.
+func WithExprEvaluator(evaluator ExprEvaluator) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.exprEvaluator = evaluator
+		})
+	})
+}
+
+// evaluate evaluates provide condition.
+func (c *Container) evaluate(expr string) (bool, error) {
+	vars := map[string]interface{}{}
+	if c.exprConfig != nil {
+		if err := c.resolve(c.exprConfig); err != nil {
+			return false, fmt.Errorf("resolve expression config: %w", err)
+		}
+		exprVars(reflect.ValueOf(c.exprConfig).Elem(), vars)
+	}
+	evaluator := c.exprEvaluator
+	if evaluator == nil {
+		evaluator = evaluateExpr
+	}
+	ok, err := evaluator(expr, vars)
+	if err != nil {
+		return false, fmt.Errorf("evaluate expression %q: %w", expr, err)
+	}
+	return ok, nil
+}
+
+// exprVars collects exported fields of config struct as expression variables.
+func exprVars(rv reflect.Value, vars map[string]interface{}) {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < rv.NumField(); i++ {
+		f := rv.Type().Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := f.Tag.Get("expr")
+		if name == "" {
+			name = strings.ToLower(f.Name[:1]) + f.Name[1:]
+		}
+		vars[name] = rv.Field(i).Interface()
+	}
+}
+
+// evaluateExpr is a default expression evaluator.
+func evaluateExpr(expr string, vars map[string]interface{}) (bool, error) {
+	tokens, err := tokenizeExpr(expr)
+	if err != nil {
+		return false, err
+	}
+	p := &exprParser{tokens: tokens, vars: vars}
+	v, err := p.or()
+	if err != nil {
+		return false, err
+	}
+	if p.pos != len(p.tokens) {
+		return false, fmt.Errorf("unexpected %s", p.tokens[p.pos].text)
+	}
+	return truth(v)
+}
+
+// exprToken is a token of expression.
+type exprToken struct {
+	// text of operator, identifier or literal
+	text string
+	// literal is true for quoted strings
+	literal bool
+}
+
+// tokenizeExpr splits expression into tokens.
+func tokenizeExpr(expr string) (tokens []exprToken, err error) {
+	for i := 0; i < len(expr); {
+		r, size := utf8.DecodeRuneInString(expr[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"),
+			strings.HasPrefix(expr[i:], "=="), strings.HasPrefix(expr[i:], "!="):
+			tokens = append(tokens, exprToken{text: expr[i : i+2]})
+			i += 2
+		case r == '!' || r == '(' || r == ')':
+			tokens = append(tokens, exprToken{text: string(r)})
+			i++
+		case r == '\'' || r == '"':
+			end := strings.IndexByte(expr[i+1:], expr[i])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, exprToken{text: expr[i+1 : i+1+end], literal: true})
+			i += end + 2
+		case isExprIdent(r):
+			start := i
+			for i < len(expr) {
+				r, size := utf8.DecodeRuneInString(expr[i:])
+				if !isExprIdent(r) {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, exprToken{text: expr[start:i]})
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", r, i)
+		}
+	}
+	return tokens, nil
+}
+
+// isExprIdent checks that r is a rune of identifier or number.
+func isExprIdent(r rune) bool {
+	return r == '_' || r == '.' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// exprParser is a recursive descent parser and evaluator of expression.
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	vars   map[string]interface{}
+	// skip is a depth of short-circuited operands, which parsed but not evaluated
+	skip int
+}
+
+// check returns evaluation error unless operand is short-circuited.
+func (p *exprParser) check(err error) error {
+	if p.skip > 0 {
+		return nil
+	}
+	return err
+}
+
+// next returns next operator token text or empty string.
+func (p *exprParser) next() string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].literal {
+		return ""
+	}
+	return p.tokens[p.pos].text
+}
+
+// short parses right operand of logical operator, which is not evaluated if skip is true.
+func (p *exprParser) short(parse func() (interface{}, error), skip bool) (interface{}, error) {
+	if skip {
+		p.skip++
+		defer func() { p.skip-- }()
+	}
+	return parse()
+}
+
+func (p *exprParser) or() (interface{}, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.next() == "||" {
+		p.pos++
+		l, err := truth(left)
+		if err = p.check(err); err != nil {
+			return nil, err
+		}
+		right, err := p.short(p.and, l)
+		if err != nil {
+			return nil, err
+		}
+		if l {
+			continue
+		}
+		if left, err = truth(right); p.check(err) != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) and() (interface{}, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.next() == "&&" {
+		p.pos++
+		l, err := truth(left)
+		if err = p.check(err); err != nil {
+			return nil, err
+		}
+		right, err := p.short(p.unary, !l)
+		if err != nil {
+			return nil, err
+		}
+		if !l {
+			continue
+		}
+		if left, err = truth(right); p.check(err) != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) unary() (interface{}, error) {
+	if p.next() == "!" {
+		p.pos++
+		v, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		b, err := truth(v)
+		if err = p.check(err); err != nil {
+			return nil, err
+		}
+		return !b, nil
+	}
+	return p.comparison()
+}
+
+func (p *exprParser) comparison() (interface{}, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	if op := p.next(); op == "==" || op == "!=" {
+		p.pos++
+		right, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		equal := fmt.Sprint(left) == fmt.Sprint(right)
+		return equal == (op == "=="), nil
+	}
+	return left, nil
+}
+
+func (p *exprParser) operand() (interface{}, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	if token.literal {
+		return token.text, nil
+	}
+	switch token.text {
+	case "(":
+		v, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("expected )")
+		}
+		p.pos++
+		return v, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case ")", "!", "&&", "||", "==", "!=":
+		return nil, fmt.Errorf("unexpected %s", token.text)
+	}
+	if v, ok := p.vars[token.text]; ok {
+		return v, nil
+	}
+	if r := rune(token.text[0]); unicode.IsDigit(r) || r == '-' {
+		return token.text, nil
+	}
+	return nil, p.check(fmt.Errorf("unknown variable %s", token.text))
+}
+
+// truth converts value to bool.
+func truth(v interface{}) (bool, error) {
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("%v is not a boolean", v)
+	}
+	return b, nil
+}
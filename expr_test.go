@@ -0,0 +1,121 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+type exprConfig struct {
+	Env      string
+	Region   string `expr:"region"`
+	Replicas int
+	Debug    bool
+	Zone     string `expr:"zône"`
+}
+
+func TestWhenExpr(t *testing.T) {
+	provided := func(t *testing.T, config exprConfig, expr string) bool {
+		c, err := di.New(
+			di.ProvideValue(&config),
+			di.ExprConfig(new(*exprConfig)),
+			di.Provide(http.NewServeMux, di.WhenExpr(expr)),
+		)
+		require.NoError(t, err)
+		has, err := c.Has(new(*http.ServeMux))
+		require.NoError(t, err)
+		return has
+	}
+
+	t.Run("expressions", func(t *testing.T) {
+		config := exprConfig{Env: "prod", Region: "eu", Replicas: 3, Debug: false, Zone: "a"}
+		for expr, expected := range map[string]bool{
+			"env == 'prod' && region != 'cn'":           true,
+			"env == 'prod' && region == 'cn'":           false,
+			`env == "dev" || region == 'eu'`:            true,
+			"!(env == 'prod')":                          false,
+			"debug":                                     false,
+			"!debug && replicas == 3":                   true,
+			"replicas != 3 || (debug || true)":          true,
+			"(env == 'prod' || env == 'dev') && !debug": true,
+			"!debug || unknown":                         true,
+			"debug && unknown == 'x'":                   false,
+			"debug && !(env || unknown)":                false,
+			"zône == 'a'":                               true,
+		} {
+			require.Equal(t, expected, provided(t, config, expr), expr)
+		}
+	})
+
+	t.Run("invalid expressions cause error", func(t *testing.T) {
+		for expr, msg := range map[string]string{
+			"env == 'prod":     "unterminated string at 7",
+			"env":              "prod is not a boolean",
+			"unknown == 'x'":   "unknown variable unknown",
+			"(debug":           "expected )",
+			"debug &&":         "unexpected end of expression",
+			"debug debug":      "unexpected debug",
+			"env == 'prod' ; ": "unexpected ';' at 14",
+			"debug && (env":    "expected )",
+		} {
+			_, err := di.New(
+				di.ProvideValue(&exprConfig{Env: "prod"}),
+				di.ExprConfig(new(*exprConfig)),
+				di.Provide(http.NewServeMux, di.WhenExpr(expr)),
+			)
+			require.Error(t, err, expr)
+			require.Contains(t, err.Error(), msg, expr)
+		}
+	})
+
+	t.Run("provide value with expression", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}, di.WhenExpr("false")),
+		)
+		require.NoError(t, err)
+		has, err := c.Has(new(*http.Server))
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("config not provided cause error", func(t *testing.T) {
+		_, err := di.New(
+			di.ExprConfig(new(*exprConfig)),
+			di.Provide(http.NewServeMux, di.WhenExpr("debug")),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve expression config: type *di_test.exprConfig not exists in the container")
+	})
+
+	t.Run("custom evaluator", func(t *testing.T) {
+		var vars map[string]interface{}
+		c, err := di.New(
+			di.ProvideValue(&exprConfig{Env: "prod"}),
+			di.ExprConfig(new(*exprConfig)),
+			di.WithExprEvaluator(func(expr string, v map[string]interface{}) (bool, error) {
+				vars = v
+				if expr == "fail" {
+					return false, errors.New("evaluator error")
+				}
+				return expr == "yes", nil
+			}),
+			di.Provide(http.NewServeMux, di.WhenExpr("yes")),
+			di.Provide(func() *http.Server { return &http.Server{} }, di.WhenExpr("no")),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "prod", vars["env"])
+		has, err := c.Has(new(*http.ServeMux))
+		require.NoError(t, err)
+		require.True(t, has)
+		has, err = c.Has(new(*http.Server))
+		require.NoError(t, err)
+		require.False(t, has)
+		err = c.Provide(http.NewServeMux, di.WhenExpr("fail"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `evaluate expression "fail": evaluator error`)
+	})
+}
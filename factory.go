@@ -0,0 +1,104 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Resolver resolves dependencies. The *Container implements it.
+type Resolver interface {
+	// Resolve resolves type and fills target pointer.
+	Resolve(ptr Pointer, options ...ResolveOption) error
+}
+
+// Factory is an alternative to constructor function for types that decided at runtime, e.g.
+// driver selected by config. Factory passed to di.Provide() like constructor. The declared type
+// used for graph validation and lookup, the dependencies resolved by factory from resolver.
+//
+//	type DriverFactory struct{}
+//
+//	func (DriverFactory) Type() reflect.Type {
+//		return reflect.TypeOf(new(Driver)).Elem()
+//	}
+//
+//	func (DriverFactory) New(r di.Resolver) (interface{}, func(), error) {
+//		var config *Config
+//		if err := r.Resolve(&config); err != nil {
+//			return nil, nil, err
+//		}
+//		return drivers[config.Driver](), nil, nil
+//	}
+type Factory interface {
+	// Type returns type of instances that factory creates.
+	Type() reflect.Type
+	// New creates instance with optional cleanup.
+	New(r Resolver) (interface{}, func(), error)
+}
+
+// newFactoryNode creates node of factory.
+func newFactoryNode(factory Factory) (*node, error) {
+	rt := factory.Type()
+	if rt == nil {
+		return nil, fmt.Errorf("%w, factory %T returns nil type", ErrInvalidConstructor, factory)
+	}
+	cmp := &factoryCompiler{factory: factory}
+	n := &node{
+		rv:       new(reflect.Value),
+		rt:       rt,
+		tags:     Tags{},
+		compiler: cmp,
+	}
+	cmp.node = n
+	return n, nil
+}
+
+// schemaResolver resolves dependencies of consumer from schema.
+type schemaResolver struct {
+	s        *defaultSchema
+	consumer *node
+}
+
+// Resolve resolves type from schema and fills target pointer.
+func (r schemaResolver) Resolve(ptr Pointer, options ...ResolveOption) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("target must be a pointer, got %s", reflect.TypeOf(ptr))
+	}
+	params := ResolveParams{}
+	for _, opt := range options {
+		opt.applyResolve(&params)
+	}
+	if params.err != nil {
+		return params.err
+	}
+	t := rv.Type().Elem()
+	n, err := r.s.search(t, query{
+		tags:     params.Tags,
+		selector: params.Selector,
+		exact:    params.Exact,
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.s.authorize(r.consumer, n); err != nil {
+		return err
+	}
+	// dependencies of factory are not declared, so they recorded when factory resolves them
+	deps := []TypeRef{{Type: t, Tags: Tags{}}}
+	for k, v := range params.Tags {
+		deps[0].Tags[k] = v
+	}
+	if isParameterStruct(t) {
+		deps = parameterDependencies(t, r.s.tagName)
+	}
+	r.consumer.dependencies = appendDependencies(r.consumer.dependencies, deps...)
+	if err := r.s.prepare(n); err != nil {
+		return err
+	}
+	v, err := n.Value(r.s)
+	if err != nil {
+		return fmt.Errorf("%s: %w", n, err)
+	}
+	rv.Elem().Set(v)
+	return nil
+}
@@ -0,0 +1,127 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+// handlerFactory is a factory of http.Handler selected by name.
+type handlerFactory struct {
+	cleanups int
+}
+
+func (f *handlerFactory) Type() reflect.Type {
+	return reflect.TypeOf(new(http.Handler)).Elem()
+}
+
+func (f *handlerFactory) New(r di.Resolver) (interface{}, func(), error) {
+	var kind string
+	if err := r.Resolve(&kind, di.Name("handler")); err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { f.cleanups++ }
+	switch kind {
+	case "mux":
+		return http.NewServeMux(), cleanup, nil
+	case "not found":
+		return http.NotFoundHandler(), cleanup, nil
+	case "invalid":
+		return 1, nil, nil
+	}
+	return nil, nil, errors.New("unknown handler")
+}
+
+func TestFactory(t *testing.T) {
+	t.Run("factory creates instance of declared type", func(t *testing.T) {
+		factory := &handlerFactory{}
+		c, err := di.New(
+			di.Const("mux", "handler"),
+			di.Provide(factory),
+			di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }),
+		)
+		require.NoError(t, err)
+		require.NoError(t, c.Verify())
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.IsType(t, &http.ServeMux{}, server.Handler)
+		c.Cleanup()
+		require.Equal(t, 1, factory.cleanups)
+	})
+
+	t.Run("factory error", func(t *testing.T) {
+		c, err := di.New(
+			di.Const("unknown", "handler"),
+			di.Provide(&handlerFactory{}),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		err = c.Resolve(&handler)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "factory_test.go:")
+		require.Contains(t, err.Error(), "http.Handler: unknown handler")
+	})
+
+	t.Run("factory dependency not exists", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(&handlerFactory{}),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		err = c.Resolve(&handler)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, di.ErrTypeNotExists))
+	})
+
+	t.Run("factory returns value of other type", func(t *testing.T) {
+		c, err := di.New(
+			di.Const("invalid", "handler"),
+			di.Provide(&handlerFactory{}),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		err = c.Resolve(&handler)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "factory returned int that not assignable to http.Handler")
+	})
+
+	t.Run("dependencies of factory authorized", func(t *testing.T) {
+		errForbidden := errors.New("forbidden")
+		c, err := di.New(
+			di.Authorize(func(consumer di.NodeInfo, target di.NodeInfo) error {
+				if target.Type.Kind() == reflect.String {
+					return errForbidden
+				}
+				return nil
+			}),
+			di.Const("mux", "handler"),
+			di.Provide(&handlerFactory{}),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		err = c.Resolve(&handler)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, errForbidden))
+		require.Contains(t, err.Error(), "http.Handler is not authorized to obtain string[name:handler]: forbidden")
+	})
+
+	t.Run("dependencies of factory recorded on resolve", func(t *testing.T) {
+		c, err := di.New(
+			di.Const("mux", "handler"),
+			di.Provide(&handlerFactory{}),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		require.NoError(t, c.Resolve(&handler))
+		require.NoError(t, c.Resolve(&handler))
+		deps := c.Graph().DependenciesOf(new(http.Handler))
+		require.Len(t, deps, 1)
+		require.Equal(t, "string", deps[0].Type)
+		require.Equal(t, di.Tags{"name": "handler"}, deps[0].Tags)
+	})
+}
@@ -0,0 +1,92 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Fill populates fields of struct that container did not construct. If struct embeds
+// di.Inject all its public fields populated like fields of provided types, otherwise only
+// fields with di tag. Useful for structs created by other frameworks, e.g. CLI commands.
+//
+//	type ServeCommand struct {
+//		Addr   string
+//		Server *http.Server `di:""`
+//		Logger *log.Logger  `di:"optional"`
+//	}
+//
+//	cmd := &ServeCommand{Addr: ":8080"}
+//	if err := container.Fill(cmd); err != nil {
+//		// handle error
+//	}
+func (c *Container) Fill(target Pointer) error {
+	if err := c.fill(target); err != nil {
+		return errWithStack(err)
+	}
+	return nil
+}
+
+func (c *Container) fill(target Pointer) error {
+	if target == nil {
+		return fmt.Errorf("target must be a pointer to struct, got nil")
+	}
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("target must be a pointer to struct, got %s", reflect.TypeOf(target))
+	}
+	rv = rv.Elem()
+	fields := fillFields(rv.Type(), c.schema.tagName)
+	indexes := make([]int, 0, len(fields))
+	for index := range fields {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		field := fields[index]
+		node, err := c.schema.find(field.rt, field.tags)
+		if err != nil && field.optional {
+			continue
+		}
+		if err == nil {
+			err = c.schema.prepare(node)
+		}
+		if err != nil {
+			return fmt.Errorf("%s field %s: %w", rv.Type(), rv.Type().Field(index).Name, err)
+		}
+		value, err := node.Value(c.schema)
+		if err != nil {
+			return fmt.Errorf("%s field %s: %s: %w", rv.Type(), rv.Type().Field(index).Name, node, err)
+		}
+		rv.Field(index).Set(value)
+	}
+	return nil
+}
+
+// fillFields returns fields of struct or pointer to struct that can be filled: public fields
+// of struct with di.Inject or fields with tag.
+func fillFields(rt reflect.Type, tagName string) map[int]field {
+	if canInject(rt) {
+		return parsePopulateFields(rt, tagName)
+	}
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := map[int]field{}
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		if _, ok := f.Tag.Lookup(tagName); !ok {
+			continue
+		}
+		if parsed, ok := inspectStructField(rt, f, tagName); ok {
+			fields[i] = parsed
+		}
+	}
+	return fields
+}
@@ -0,0 +1,82 @@
+package di_test
+
+import (
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestContainer_Fill(t *testing.T) {
+	t.Run("fill tagged fields", func(t *testing.T) {
+		type Command struct {
+			Addr    string
+			Client  *http.Client   `di:""`
+			Public  *http.Server   `di:"name=public"`
+			Logger  *log.Logger    `di:"optional"`
+			Mux     *http.ServeMux `di:"skip"`
+			handler http.Handler   `di:""`
+		}
+		client := &http.Client{}
+		public := &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(client),
+			di.ProvideValue(public, di.Tags{"name": "public"}),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "private"}),
+			di.ProvideValue(http.NewServeMux()),
+		)
+		require.NoError(t, err)
+		cmd := &Command{Addr: ":8080"}
+		require.NoError(t, c.Fill(cmd))
+		require.Equal(t, ":8080", cmd.Addr)
+		require.Same(t, client, cmd.Client)
+		require.Same(t, public, cmd.Public)
+		require.Nil(t, cmd.Logger)
+		require.Nil(t, cmd.Mux)
+		require.Nil(t, cmd.handler)
+	})
+
+	t.Run("fill struct with di.Inject", func(t *testing.T) {
+		type Fixture struct {
+			di.Inject
+			Server *http.Server
+			Mux    *http.ServeMux
+		}
+		server := &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(server),
+			di.Provide(http.NewServeMux),
+		)
+		require.NoError(t, err)
+		var fixture Fixture
+		require.NoError(t, c.Fill(&fixture))
+		require.Same(t, server, fixture.Server)
+		require.NotNil(t, fixture.Mux)
+	})
+
+	t.Run("missing dependency cause error", func(t *testing.T) {
+		type Command struct {
+			Server *http.Server `di:""`
+		}
+		c, err := di.New()
+		require.NoError(t, err)
+		err = c.Fill(&Command{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "fill_test.go:")
+		require.Contains(t, err.Error(), "di_test.Command field Server: type *http.Server not exists in the container")
+	})
+
+	t.Run("invalid target cause error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		err = c.Fill(http.Server{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "target must be a pointer to struct, got http.Server")
+		err = c.Fill(nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "target must be a pointer to struct, got nil")
+	})
+}
@@ -0,0 +1,79 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ResolveAs resolves type T from container. It returns zero value of T on error.
+//
+//	server, err := di.ResolveAs[*http.Server](container, di.Name("public"))
+//	if err != nil {
+//		// handle error
+//	}
+func ResolveAs[T any](c *Container, options ...ResolveOption) (T, error) {
+	var result T
+	if err := c.resolve(&result, options...); err != nil {
+		var zero T
+		return zero, errWithStack(err)
+	}
+	return result, nil
+}
+
+// Const returns container option that provides value of type T with name. Unlike
+// di.ProvideValue() the value provided as T, even T is an interface.
+//
+//	container, err := di.New(
+//		di.Const(8080, "port"),
+//		di.Const(5*time.Second, "timeout"),
+//	)
+func Const[T any](value T, name string, options ...ProvideOption) Option {
+	frame := stacktrace(0)
+	return option(func(c *diopts) {
+		c.provides = append(c.provides, provideOptions{
+			frame,
+			func() T { return value },
+			append([]ProvideOption{Tags{"name": name}}, options...),
+		})
+	})
+}
+
+// InvokeResult calls the function with dependencies as arguments and returns its result. The
+// function must have signature func(deps...) T or func(deps...) (T, error).
+//
+//	handler, err := di.InvokeResult[http.Handler](container, func(mux *http.ServeMux) http.Handler {
+//		return middleware(mux)
+//	})
+func InvokeResult[T any](c *Container, invocation Invocation, options ...InvokeOption) (T, error) {
+	result, err := invokeResult[T](c, invocation, options...)
+	if err != nil && knownError(err) {
+		return result, errWithStack(err)
+	}
+	return result, err
+}
+
+// invokeResult calls invocation and returns its typed result.
+func invokeResult[T any](c *Container, invocation Invocation, options ...InvokeOption) (T, error) {
+	var zero T
+	params := InvokeParams{}
+	for _, opt := range options {
+		opt.apply(&params)
+	}
+	fn, valid := inspectFunction(invocation)
+	if !valid || !validateResultInvocation(fn, reflect.TypeOf(&zero).Elem()) {
+		return zero, fmt.Errorf("%w, got %s", ErrInvalidInvocation, reflect.TypeOf(invocation))
+	}
+	args, err := c.invocationArgs(fn, params)
+	if err != nil {
+		return zero, err
+	}
+	res := funcResult(fn.Call(args))
+	if len(res) == 2 {
+		if err := res.error(1); err != nil {
+			return zero, err
+		}
+	}
+	// result of interface type can be nil
+	result, _ := res.value().Interface().(T)
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package di_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestResolveAs(t *testing.T) {
+	t.Run("resolve type", func(t *testing.T) {
+		server := &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(server),
+		)
+		require.NoError(t, err)
+		resolved, err := di.ResolveAs[*http.Server](c)
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%p", server), fmt.Sprintf("%p", resolved))
+	})
+
+	t.Run("resolve type with options", func(t *testing.T) {
+		public := &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(public, di.Tags{"name": "public"}),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "private"}),
+		)
+		require.NoError(t, err)
+		resolved, err := di.ResolveAs[*http.Server](c, di.Name("public"))
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%p", public), fmt.Sprintf("%p", resolved))
+		servers, err := di.ResolveAs[[]*http.Server](c)
+		require.NoError(t, err)
+		require.Len(t, servers, 2)
+	})
+
+	t.Run("resolve error returns zero value", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		resolved, err := di.ResolveAs[*http.Server](c)
+		require.Error(t, err)
+		require.Nil(t, resolved)
+		require.Contains(t, err.Error(), "generic_test.go:")
+		require.Contains(t, err.Error(), "type *http.Server not exists in the container")
+	})
+}
+
+func TestInvokeResult(t *testing.T) {
+	t.Run("invoke with result", func(t *testing.T) {
+		mux := &http.ServeMux{}
+		c, err := di.New(
+			di.ProvideValue(mux),
+		)
+		require.NoError(t, err)
+		handler, err := di.InvokeResult[http.Handler](c, func(mux *http.ServeMux) *http.ServeMux {
+			return mux
+		})
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%p", mux), fmt.Sprintf("%p", handler))
+	})
+
+	t.Run("invoke with result and error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		addr, err := di.InvokeResult[string](c, func() (string, error) {
+			return ":80", nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, ":80", addr)
+		addr, err = di.InvokeResult[string](c, func() (string, error) {
+			return ":80", errors.New("invoke error")
+		})
+		require.EqualError(t, err, "invoke error")
+		require.Equal(t, "", addr)
+	})
+
+	t.Run("invoke with nil interface result", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		handler, err := di.InvokeResult[http.Handler](c, func() http.Handler { return nil })
+		require.NoError(t, err)
+		require.Nil(t, handler)
+	})
+
+	t.Run("invoke with invalid signature cause error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		_, err = di.InvokeResult[string](c, func() int { return 0 })
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "generic_test.go:")
+		require.Contains(t, err.Error(), "invalid invocation signature, got func() int")
+		_, err = di.InvokeResult[string](c, func() {})
+		require.Error(t, err)
+	})
+
+	t.Run("invoke with not existing dependency cause error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		_, err = di.InvokeResult[string](c, func(server *http.Server) string { return server.Addr })
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type *http.Server not exists in the container")
+	})
+}
+
+func TestConst(t *testing.T) {
+	t.Run("literal provided with name", func(t *testing.T) {
+		c, err := di.New(
+			di.Const(8080, "port"),
+			di.Const(5*time.Second, "timeout"),
+		)
+		require.NoError(t, err)
+		port, err := di.ResolveAs[int](c, di.Name("port"))
+		require.NoError(t, err)
+		require.Equal(t, 8080, port)
+		timeout, err := di.ResolveAs[time.Duration](c, di.Name("timeout"))
+		require.NoError(t, err)
+		require.Equal(t, 5*time.Second, timeout)
+	})
+
+	t.Run("value provided as interface", func(t *testing.T) {
+		c, err := di.New(
+			di.Const[http.Handler](http.NewServeMux(), "api"),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		require.NoError(t, c.Resolve(&handler, di.Name("api")))
+		var mux *http.ServeMux
+		has, err := c.Has(&mux)
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("provide options applied", func(t *testing.T) {
+		c, err := di.New(
+			di.Const("secret", "password", di.Sensitive()),
+		)
+		require.NoError(t, err)
+		require.True(t, c.Blueprint().Definitions[2].Sensitive)
+	})
+}
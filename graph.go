@@ -0,0 +1,275 @@
+package di
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// GraphNodeKind is a kind of dependency graph node.
+type GraphNodeKind string
+
+const (
+	// GraphProvider is a provided type.
+	GraphProvider GraphNodeKind = "provider"
+	// GraphInterface is an interface registered with di.As().
+	GraphInterface GraphNodeKind = "interface"
+	// GraphGroup is a group of types that some definition depends on.
+	GraphGroup GraphNodeKind = "group"
+)
+
+// Graph is a read-only dependency graph of container definitions. See Container.Graph().
+type Graph struct {
+	nodes []GraphNode
+	edges []GraphEdge
+	// types is a types of nodes by node index
+	types []reflect.Type
+}
+
+// GraphNode is a node of dependency graph.
+type GraphNode struct {
+	// ID is a unique identifier of node in the graph.
+	ID string `json:"id"`
+	// Kind is a kind of node.
+	Kind GraphNodeKind `json:"kind"`
+	// Type is a string representation of node type.
+	Type string `json:"type"`
+	// Tags is a tags of node. Tags of sensitive definitions are redacted.
+	Tags Tags `json:"tags,omitempty"`
+	// Lifetime is a lifetime of node instances.
+	Lifetime Lifetime `json:"lifetime"`
+	// Source is a location where type was provided.
+	Source string `json:"source,omitempty"`
+}
+
+// GraphEdge is a directed edge of dependency graph. Provider edges point to its dependencies,
+// interface edges point to implementation and group edges point to group members.
+type GraphEdge struct {
+	// From is an identifier of dependent node.
+	From string `json:"from"`
+	// To is an identifier of dependency node.
+	To string `json:"to"`
+	// Tags is a tags of dependency requested by dependent node.
+	Tags Tags `json:"tags,omitempty"`
+}
+
+// Graph returns dependency graph of the container definitions. Definitions of ancestors
+// included. The graph built from declared dependencies without instantiation, dependencies
+// that can't be found are not included.
+//
+//	if err := container.Graph().DOT(os.Stdout); err != nil {
+//		// handle error
+//	}
+func (c *Container) Graph() *Graph {
+	var nodes []*node
+	for _, n := range c.schema.all() {
+		if !n.implicit {
+			nodes = append(nodes, n)
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].String() == nodes[j].String() {
+			return fmt.Sprint(nodes[i].frame) < fmt.Sprint(nodes[j].frame)
+		}
+		return nodes[i].String() < nodes[j].String()
+	})
+	b := &graphBuilder{
+		graph:  &Graph{},
+		ids:    map[*node]string{},
+		groups: map[string]string{},
+	}
+	for _, n := range nodes {
+		kind := GraphProvider
+		if n.origin != nil {
+			kind = GraphInterface
+		}
+		b.add(n, kind)
+	}
+	for _, n := range nodes {
+		if n.origin != nil {
+			b.edge(n, n.origin, nil)
+			continue
+		}
+		for _, dep := range n.dependencies {
+			target, err := c.schema.find(dep.Type, dep.Tags)
+			if err != nil || target.implicit {
+				continue
+			}
+			b.edge(n, target, dep.Tags)
+		}
+	}
+	return b.graph
+}
+
+// Nodes returns graph nodes.
+func (g *Graph) Nodes() []GraphNode {
+	return append([]GraphNode(nil), g.nodes...)
+}
+
+// Edges returns graph edges.
+func (g *Graph) Edges() []GraphEdge {
+	return append([]GraphEdge(nil), g.edges...)
+}
+
+// DependenciesOf returns nodes that definitions of type, which ptr points to, directly depend
+// on. Options like di.Tags narrow definitions.
+//
+//	deps := container.Graph().DependenciesOf(new(*http.Server), di.Name("public"))
+func (g *Graph) DependenciesOf(ptr Pointer, options ...ResolveOption) []GraphNode {
+	matched := g.match(ptr, options)
+	var ids []string
+	for _, e := range g.edges {
+		if matched[e.From] {
+			ids = append(ids, e.To)
+		}
+	}
+	return g.lookup(ids)
+}
+
+// DependentsOf returns nodes that directly depend on definitions of type, which ptr points
+// to. Options like di.Tags narrow definitions.
+//
+//	dependents := container.Graph().DependentsOf(new(http.Handler))
+func (g *Graph) DependentsOf(ptr Pointer, options ...ResolveOption) []GraphNode {
+	matched := g.match(ptr, options)
+	var ids []string
+	for _, e := range g.edges {
+		if matched[e.To] {
+			ids = append(ids, e.From)
+		}
+	}
+	return g.lookup(ids)
+}
+
+// match returns identifiers of nodes with type that ptr points to and matching tags.
+func (g *Graph) match(ptr Pointer, options []ResolveOption) map[string]bool {
+	params := ResolveParams{}
+	for _, opt := range options {
+		opt.applyResolve(&params)
+	}
+	rt := reflect.TypeOf(ptr)
+	if rt == nil || rt.Kind() != reflect.Ptr {
+		return nil
+	}
+	matched := map[string]bool{}
+	for i, n := range g.nodes {
+		if g.types[i] == rt.Elem() && n.Tags.match(params.Tags) && n.Tags.Match(params.Selector) {
+			matched[n.ID] = true
+		}
+	}
+	return matched
+}
+
+// lookup returns nodes by identifiers in order of graph nodes.
+func (g *Graph) lookup(ids []string) (nodes []GraphNode) {
+	set := map[string]bool{}
+	for _, id := range ids {
+		set[id] = true
+	}
+	for _, n := range g.nodes {
+		if set[n.ID] {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// DOT writes graph in Graphviz DOT format.
+//
+//	container.Graph().DOT(file) // dot -Tsvg -o graph.svg graph.dot
+func (g *Graph) DOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph di {"); err != nil {
+		return err
+	}
+	shapes := map[GraphNodeKind]string{
+		GraphProvider:  "box",
+		GraphInterface: "ellipse",
+		GraphGroup:     "folder",
+	}
+	for _, n := range g.nodes {
+		if _, err := fmt.Fprintf(w, "\t%s [label=%q shape=%s];\n", n.ID, n.Type+n.Tags.String(), shapes[n.Kind]); err != nil {
+			return err
+		}
+	}
+	for _, e := range g.edges {
+		var err error
+		if len(e.Tags) > 0 {
+			_, err = fmt.Fprintf(w, "\t%s -> %s [label=%q];\n", e.From, e.To, e.Tags.String())
+		} else {
+			_, err = fmt.Fprintf(w, "\t%s -> %s;\n", e.From, e.To)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// JSON returns graph encoded as JSON.
+//
+//	data, err := container.Graph().JSON()
+//	if err != nil {
+//		// handle error
+//	}
+func (g *Graph) JSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Nodes []GraphNode `json:"nodes"`
+		Edges []GraphEdge `json:"edges"`
+	}{g.nodes, g.edges})
+}
+
+// graphBuilder builds dependency graph.
+type graphBuilder struct {
+	graph *Graph
+	// ids is a graph identifiers of nodes
+	ids map[*node]string
+	// groups is a graph identifiers of groups by string representation
+	groups map[string]string
+}
+
+// add adds node to the graph.
+func (b *graphBuilder) add(n *node, kind GraphNodeKind) string {
+	id := fmt.Sprintf("n%d", len(b.graph.nodes))
+	gn := GraphNode{
+		ID:       id,
+		Kind:     kind,
+		Type:     n.rt.String(),
+		Lifetime: n.lifetime,
+		Source:   n.frame.source(),
+	}
+	if len(n.tags) > 0 && !n.sensitive {
+		gn.Tags = n.tags
+	}
+	b.graph.nodes = append(b.graph.nodes, gn)
+	b.graph.types = append(b.graph.types, n.rt)
+	b.ids[n] = id
+	return id
+}
+
+// edge adds edge from node to its dependency. Group dependency added with its members.
+func (b *graphBuilder) edge(from, to *node, tags Tags) {
+	id, ok := b.ids[to]
+	if cmp, group := to.compiler.(*groupCompiler); !ok && group {
+		if id, ok = b.groups[to.String()]; !ok {
+			id = b.add(to, GraphGroup)
+			b.groups[to.String()] = id
+			for _, member := range cmp.matched {
+				b.edge(to, member, nil)
+			}
+		}
+	}
+	if id == "" {
+		return
+	}
+	if to.sensitive || len(tags) == 0 {
+		tags = nil
+	}
+	b.graph.edges = append(b.graph.edges, GraphEdge{
+		From: b.ids[from],
+		To:   id,
+		Tags: tags,
+	})
+}
@@ -0,0 +1,186 @@
+package di_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestContainer_Graph(t *testing.T) {
+	t.Run("empty container", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, c.Graph().DOT(&buf))
+		require.Equal(t, `digraph di {
+	n0 [label="*di.Container" shape=box];
+	n1 [label="di.BuildInfo" shape=box];
+}
+`, buf.String())
+	})
+
+	t.Run("providers, interfaces and groups", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler))),
+			di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }, di.Tags{"name": "public"}),
+			di.Provide(func(handlers []http.Handler) *http.Client { return &http.Client{} }),
+		)
+		require.NoError(t, err)
+		graph := c.Graph()
+		nodes := graph.Nodes()
+		// source locations checked in json test
+		for i := range nodes {
+			nodes[i].Source = ""
+		}
+		require.Equal(t, []di.GraphNode{
+			{ID: "n0", Kind: di.GraphProvider, Type: "*di.Container"},
+			{ID: "n1", Kind: di.GraphProvider, Type: "*http.Client"},
+			{ID: "n2", Kind: di.GraphProvider, Type: "*http.ServeMux"},
+			{ID: "n3", Kind: di.GraphProvider, Type: "*http.Server", Tags: di.Tags{"name": "public"}},
+			{ID: "n4", Kind: di.GraphProvider, Type: "di.BuildInfo"},
+			{ID: "n5", Kind: di.GraphInterface, Type: "http.Handler"},
+			{ID: "n6", Kind: di.GraphGroup, Type: "[]http.Handler"},
+		}, nodes)
+		require.Equal(t, []di.GraphEdge{
+			{From: "n6", To: "n5"},
+			{From: "n1", To: "n6"},
+			{From: "n3", To: "n5"},
+			{From: "n5", To: "n2"},
+		}, graph.Edges())
+		var buf bytes.Buffer
+		require.NoError(t, graph.DOT(&buf))
+		require.Equal(t, `digraph di {
+	n0 [label="*di.Container" shape=box];
+	n1 [label="*http.Client" shape=box];
+	n2 [label="*http.ServeMux" shape=box];
+	n3 [label="*http.Server[name:public]" shape=box];
+	n4 [label="di.BuildInfo" shape=box];
+	n5 [label="http.Handler" shape=ellipse];
+	n6 [label="[]http.Handler" shape=folder];
+	n6 -> n5;
+	n1 -> n6;
+	n3 -> n5;
+	n5 -> n2;
+}
+`, buf.String())
+	})
+
+	t.Run("tags on edges", func(t *testing.T) {
+		type Server struct {
+			di.Inject
+			Public  *http.Server `di:"name=public"`
+			Private *http.Server `di:"name=private"`
+		}
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"name": "public"}),
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"name": "private"}, di.Sensitive()),
+			di.Provide(func() *Server { return &Server{} }),
+		)
+		require.NoError(t, err)
+		graph := c.Graph()
+		nodes := graph.Nodes()
+		// source locations checked in json test
+		for i := range nodes {
+			nodes[i].Source = ""
+		}
+		require.Equal(t, []di.GraphNode{
+			{ID: "n0", Kind: di.GraphProvider, Type: "*di.Container"},
+			{ID: "n1", Kind: di.GraphProvider, Type: "*di_test.Server"},
+			{ID: "n2", Kind: di.GraphProvider, Type: "*http.Server"},
+			{ID: "n3", Kind: di.GraphProvider, Type: "*http.Server", Tags: di.Tags{"name": "public"}},
+			{ID: "n4", Kind: di.GraphProvider, Type: "di.BuildInfo"},
+		}, nodes)
+		require.Equal(t, []di.GraphEdge{
+			{From: "n1", To: "n3", Tags: di.Tags{"name": "public"}},
+			{From: "n1", To: "n2"},
+		}, graph.Edges())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler)), di.WithLifetime(di.Scoped)),
+			di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }),
+		)
+		require.NoError(t, err)
+		data, err := c.Graph().JSON()
+		require.NoError(t, err)
+		var graph struct {
+			Nodes []map[string]interface{} `json:"nodes"`
+			Edges []map[string]interface{} `json:"edges"`
+		}
+		require.NoError(t, json.Unmarshal(data, &graph))
+		require.Len(t, graph.Nodes, 5)
+		mux := graph.Nodes[1]
+		require.Equal(t, "n1", mux["id"])
+		require.Equal(t, "provider", mux["kind"])
+		require.Equal(t, "*http.ServeMux", mux["type"])
+		require.Equal(t, "scoped", mux["lifetime"])
+		require.Contains(t, mux["source"], "graph_test.go:")
+		require.Equal(t, "singleton", graph.Nodes[2]["lifetime"])
+		require.Equal(t, []map[string]interface{}{
+			{"from": "n2", "to": "n4"},
+			{"from": "n4", "to": "n1"},
+		}, graph.Edges)
+	})
+
+	t.Run("dependencies and dependents", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler))),
+			di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }, di.Tags{"name": "public"}),
+			di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }, di.Tags{"name": "private"}),
+			di.Provide(func(handlers []http.Handler) *http.Client { return &http.Client{} }),
+		)
+		require.NoError(t, err)
+		graph := c.Graph()
+		types := func(nodes []di.GraphNode) (types []string) {
+			for _, n := range nodes {
+				types = append(types, n.Type+n.Tags.String())
+			}
+			return types
+		}
+		require.Equal(t, []string{"http.Handler"}, types(graph.DependenciesOf(new(*http.Server), di.Name("public"))))
+		require.Equal(t, []string{"*http.ServeMux"}, types(graph.DependenciesOf(new(http.Handler))))
+		require.Equal(t, []string{"*http.Server[name:private]", "*http.Server[name:public]", "[]http.Handler"}, types(graph.DependentsOf(new(http.Handler))))
+		require.Equal(t, []string{"http.Handler"}, types(graph.DependentsOf(new(*http.ServeMux))))
+		require.Empty(t, graph.DependentsOf(new(*http.Server)))
+		require.Empty(t, graph.DependenciesOf(new(*http.Request)))
+		require.Empty(t, graph.DependenciesOf(nil))
+	})
+
+	t.Run("fields of parameter struct are dependencies", func(t *testing.T) {
+		type Params struct {
+			di.Inject
+			Mux    *http.ServeMux `di:"name=public"`
+			Client *http.Client   `di:"optional"`
+		}
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.WithName("public")),
+			di.Provide(func(p Params) *http.Server { return &http.Server{Handler: p.Mux} }),
+			di.ProvideFactory(new(func(url string) *http.Request), func(url string, p Params) *http.Request {
+				return &http.Request{}
+			}),
+			di.Shadow(func() *http.Transport { return &http.Transport{} }, func(p Params) *http.Transport {
+				return &http.Transport{}
+			}, func(a, b interface{}) error { return nil }),
+		)
+		require.NoError(t, err)
+		graph := c.Graph()
+		types := func(nodes []di.GraphNode) (types []string) {
+			for _, n := range nodes {
+				types = append(types, n.Type+n.Tags.String())
+			}
+			return types
+		}
+		require.Equal(t, []string{"*http.ServeMux[name:public]"}, types(graph.DependenciesOf(new(*http.Server))))
+		require.Equal(t, []string{"*http.ServeMux[name:public]"}, types(graph.DependenciesOf(new(func(url string) *http.Request))))
+		require.Equal(t, []string{"*http.ServeMux[name:public]"}, types(graph.DependenciesOf(new(*http.Transport))))
+		var buf bytes.Buffer
+		require.NoError(t, graph.DOT(&buf))
+		require.Contains(t, buf.String(), "->")
+	})
+}
@@ -0,0 +1,78 @@
+package di
+
+import (
+	"sync"
+)
+
+// ScopeHandle is an explicit handle of scope that can be passed to goroutines which can't
+// thread context.Context. It resolves scoped instances of its scope and waits goroutines
+// started by Go() before scope cleanup.
+//
+// Pitfalls:
+//
+//   - Container is not safe for concurrent use. The handle serializes its own resolves, but
+//     resolves of different handles that build the same parent singletons concurrently race.
+//     Construct shared singletons before starting goroutines, e.g. with di.EagerInit().
+//   - Handle must not be used after Close(). Instances resolved from it may be cleaned up.
+//   - Goroutines started without Go() are not waited by Close().
+type ScopeHandle struct {
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+	once   sync.Once
+	scope  *Container
+	closed bool
+}
+
+// OpenScope creates child container with options and returns handle of it.
+//
+//	handle, err := container.OpenScope(di.ProvideValue(job))
+//	if err != nil {
+//		// handle error
+//	}
+//	defer handle.Close()
+//	handle.Go(func(h *di.ScopeHandle) {
+//		var logger *JobLogger
+//		if err := h.Resolve(&logger); err != nil {
+//			// handle error
+//		}
+//	})
+func (c *Container) OpenScope(options ...Option) (*ScopeHandle, error) {
+	scope, err := c.NewChild(options...)
+	if err != nil {
+		return nil, err
+	}
+	return &ScopeHandle{scope: scope}, nil
+}
+
+// Resolve resolves type of scope. Resolves of the handle serialized.
+func (h *ScopeHandle) Resolve(ptr Pointer, options ...ResolveOption) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return errWithStack(ErrScopeClosed)
+	}
+	if err := h.scope.resolve(ptr, options...); err != nil {
+		return errWithStack(err)
+	}
+	return nil
+}
+
+// Go runs fn in new goroutine with the handle. Close() waits it.
+func (h *ScopeHandle) Go(fn func(h *ScopeHandle)) {
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		fn(h)
+	}()
+}
+
+// Close waits goroutines started by Go() and cleanups the scope. Close runs once.
+func (h *ScopeHandle) Close() {
+	h.once.Do(func() {
+		h.wg.Wait()
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.closed = true
+		h.scope.Cleanup()
+	})
+}
@@ -0,0 +1,67 @@
+package di_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestContainer_OpenScope(t *testing.T) {
+	t.Run("goroutines resolve scoped instance", func(t *testing.T) {
+		cleaned := 0
+		c, err := di.New(
+			di.Provide(func(r *http.Request) (*http.Server, func()) {
+				return &http.Server{Addr: r.URL.Path}, func() { cleaned++ }
+			}, di.WithLifetime(di.Scoped)),
+		)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodGet, "/job", nil)
+		require.NoError(t, err)
+		handle, err := c.OpenScope(di.ProvideValue(req))
+		require.NoError(t, err)
+		var mu sync.Mutex
+		var servers []*http.Server
+		for i := 0; i < 4; i++ {
+			handle.Go(func(h *di.ScopeHandle) {
+				var server *http.Server
+				require.NoError(t, h.Resolve(&server))
+				mu.Lock()
+				servers = append(servers, server)
+				mu.Unlock()
+			})
+		}
+		handle.Close()
+		require.Len(t, servers, 4)
+		for _, server := range servers {
+			require.True(t, servers[0] == server)
+			require.Equal(t, "/job", server.Addr)
+		}
+		require.Equal(t, 1, cleaned)
+		handle.Close()
+		require.Equal(t, 1, cleaned)
+	})
+
+	t.Run("resolve from closed scope cause error", func(t *testing.T) {
+		c, err := di.New(di.Provide(http.NewServeMux))
+		require.NoError(t, err)
+		handle, err := c.OpenScope()
+		require.NoError(t, err)
+		handle.Close()
+		var mux *http.ServeMux
+		err = handle.Resolve(&mux)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "handle_test.go:")
+		require.Contains(t, err.Error(), "scope closed")
+	})
+
+	t.Run("open scope with invalid options cause error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		_, err = c.OpenScope(di.Provide(nil))
+		require.Error(t, err)
+	})
+}
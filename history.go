@@ -0,0 +1,144 @@
+package di
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// HistoryEventKind is a kind of recorded container operation.
+type HistoryEventKind string
+
+const (
+	// HistoryProvide is a registration of definition.
+	HistoryProvide HistoryEventKind = "provide"
+	// HistoryResolve is a resolve of type by Container.Resolve() and similar methods.
+	HistoryResolve HistoryEventKind = "resolve"
+	// HistoryBuild is a construction of instance.
+	HistoryBuild HistoryEventKind = "build"
+	// HistoryCleanup is a call of instance cleanup.
+	HistoryCleanup HistoryEventKind = "cleanup"
+)
+
+// HistoryEvent is a recorded container operation.
+type HistoryEvent struct {
+	// Kind is a kind of operation.
+	Kind HistoryEventKind
+	// Type is a string representation of type with tags. Tags of sensitive definitions are
+	// redacted.
+	Type string
+	// Start is a time when operation started.
+	Start time.Time
+	// Duration is a duration of operation.
+	Duration time.Duration
+	// Err is an error of operation.
+	Err error
+}
+
+// History is a log of container operations in order of completion. See di.RecordHistory().
+type History []HistoryEvent
+
+// RecordHistory returns container option that enables recording of container operations:
+// provides, resolves, builds and cleanups. Recording has overhead, use it in development to
+// reconstruct what container did, e.g. during problematic startup. Child containers record
+// into history of container.
+//
+//	container, err := di.New(
+//		di.RecordHistory(),
+//		di.Provide(NewServer),
+//	)
+//	defer container.History().ChromeTrace(file)
+func RecordHistory() Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			if c.schema.history == nil {
+				c.schema.history = &history{}
+			}
+		})
+	})
+}
+
+// History returns recorded container operations. It is empty if recording is not enabled by
+// di.RecordHistory().
+func (c *Container) History() History {
+	if c.schema.history == nil {
+		return nil
+	}
+	c.schema.history.mu.Lock()
+	defer c.schema.history.mu.Unlock()
+	return append(History(nil), c.schema.history.events...)
+}
+
+// ChromeTrace writes history in trace event format that can be opened by chrome://tracing or
+// https://ui.perfetto.dev.
+func (h History) ChromeTrace(w io.Writer) error {
+	type traceEvent struct {
+		Name      string            `json:"name"`
+		Category  string            `json:"cat"`
+		Phase     string            `json:"ph"`
+		Timestamp int64             `json:"ts"`
+		Duration  int64             `json:"dur"`
+		PID       int               `json:"pid"`
+		TID       int               `json:"tid"`
+		Args      map[string]string `json:"args,omitempty"`
+	}
+	events := make([]traceEvent, 0, len(h))
+	for _, e := range h {
+		event := traceEvent{
+			Name:      e.Type,
+			Category:  string(e.Kind),
+			Phase:     "X",
+			Timestamp: e.Start.UnixNano() / int64(time.Microsecond),
+			Duration:  int64(e.Duration / time.Microsecond),
+			PID:       1,
+			TID:       1,
+		}
+		if e.Err != nil {
+			event.Args = map[string]string{"error": e.Err.Error()}
+		}
+		events = append(events, event)
+	}
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}{events})
+}
+
+// history is a recorder of container operations.
+type history struct {
+	mu     sync.Mutex
+	events []HistoryEvent
+}
+
+// record records operation of kind on node that started at start. It does nothing if recording
+// is not enabled.
+func (s *defaultSchema) record(kind HistoryEventKind, n *node, start time.Time, err error) {
+	if s.history == nil {
+		return
+	}
+	name := n.rt.String()
+	if !n.sensitive {
+		name = n.String()
+	}
+	s.history.mu.Lock()
+	defer s.history.mu.Unlock()
+	s.history.events = append(s.history.events, HistoryEvent{
+		Kind:     kind,
+		Type:     name,
+		Start:    start,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
+
+// recordCleanup returns cleanup that records its call.
+func (s *defaultSchema) recordCleanup(n *node, cleanup func()) func() {
+	if s.history == nil {
+		return cleanup
+	}
+	return func() {
+		start := time.Now()
+		cleanup()
+		s.record(HistoryCleanup, n, start, nil)
+	}
+}
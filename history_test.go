@@ -0,0 +1,93 @@
+package di_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestContainer_History(t *testing.T) {
+	t.Run("history disabled by default", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		require.NoError(t, c.Resolve(&mux))
+		require.Empty(t, c.History())
+	})
+
+	t.Run("operations recorded", func(t *testing.T) {
+		c, err := di.New(
+			di.RecordHistory(),
+			di.Provide(http.NewServeMux, di.As(new(http.Handler))),
+			di.Provide(func(handler http.Handler) (*http.Server, func()) {
+				return &http.Server{Handler: handler}, func() {}
+			}, di.Tags{"name": "public"}),
+			di.Provide(func() (*http.Client, error) { return nil, errors.New("client error") }),
+			di.ProvideValue("secret", di.Tags{"key": "token"}, di.Sensitive()),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		var client *http.Client
+		require.Error(t, c.Resolve(&client))
+		var secret string
+		require.NoError(t, c.Resolve(&secret))
+		c.Cleanup()
+		type event struct {
+			kind di.HistoryEventKind
+			typ  string
+			err  bool
+		}
+		var events []event
+		for _, e := range c.History() {
+			events = append(events, event{e.Kind, e.Type, e.Err != nil})
+			require.False(t, e.Start.IsZero())
+		}
+		require.Equal(t, []event{
+			{di.HistoryProvide, "string", false},
+			{di.HistoryProvide, "*http.ServeMux", false},
+			{di.HistoryProvide, "*http.Server[name:public]", false},
+			{di.HistoryProvide, "*http.Client", false},
+			{di.HistoryBuild, "http.Handler", false},
+			{di.HistoryBuild, "*http.Server[name:public]", false},
+			{di.HistoryResolve, "*http.Server[name:public]", false},
+			{di.HistoryBuild, "*http.Client", true},
+			{di.HistoryResolve, "*http.Client", true},
+			{di.HistoryBuild, "string", false},
+			{di.HistoryResolve, "string", false},
+			{di.HistoryCleanup, "*http.Server[name:public]", false},
+		}, events)
+	})
+
+	t.Run("chrome trace", func(t *testing.T) {
+		c, err := di.New(
+			di.RecordHistory(),
+			di.Provide(func() (*http.Client, error) { return nil, errors.New("client error") }),
+		)
+		require.NoError(t, err)
+		child, err := c.NewChild()
+		require.NoError(t, err)
+		var client *http.Client
+		require.Error(t, child.Resolve(&client))
+		var buf bytes.Buffer
+		require.NoError(t, c.History().ChromeTrace(&buf))
+		var trace struct {
+			TraceEvents []map[string]interface{} `json:"traceEvents"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+		require.Len(t, trace.TraceEvents, 4)
+		build := trace.TraceEvents[2]
+		require.Equal(t, "*http.Client", build["name"])
+		require.Equal(t, "build", build["cat"])
+		require.Equal(t, "X", build["ph"])
+		require.Equal(t, map[string]interface{}{"error": "client error"}, build["args"])
+	})
+}
@@ -0,0 +1,68 @@
+package di
+
+import (
+	"reflect"
+	"strings"
+)
+
+// AsImplemented returns provide option that binds provided type to each interface it
+// implements from packages, as if each of them specified with di.As(). The package is an
+// import path or pattern with "/..." suffix that matches package and its subpackages. Without
+// packages interfaces of all packages bound. Interfaces bound on first lookup, because Go does
+// not allow to list interfaces of package.
+//
+//	container, err := di.New(
+//		di.Provide(NewUserRepository, di.AsImplemented("github.com/myorg/app/domain/...")),
+//	)
+//	var finder domain.UserFinder // resolved as *UserRepository
+func AsImplemented(packages ...string) ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		// not nil slice enables binding
+		params.Implemented = append(append([]string{}, params.Implemented...), packages...)
+	})
+}
+
+// implement binds interface t to implementers of schema.
+func (s *defaultSchema) implement(t reflect.Type) {
+	// unnamed interfaces like interface{} and error are not bound
+	if len(s.implementers) == 0 || t.Kind() != reflect.Interface || t.PkgPath() == "" {
+		return
+	}
+	for _, n := range s.implementers {
+		if !n.rt.Implements(t) || n.implements(t) || !matchPackage(t.PkgPath(), n.implemented) {
+			continue
+		}
+		n.interfaces = append(n.interfaces, t)
+		s.register(n.as(t))
+	}
+}
+
+// implements checks that node registered as interface t.
+func (n *node) implements(t reflect.Type) bool {
+	for _, cur := range n.interfaces {
+		if cur == t {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPackage checks that package path matches any of patterns. Empty patterns match any
+// package.
+func matchPackage(path string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if prefix := strings.TrimSuffix(pattern, "/..."); prefix != pattern {
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if path == pattern {
+			return true
+		}
+	}
+	return false
+}
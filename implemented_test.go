@@ -0,0 +1,94 @@
+package di_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestAsImplemented(t *testing.T) {
+	t.Run("interfaces of package bound", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		c, err := di.New(
+			di.ProvideValue(buf, di.AsImplemented("io")),
+		)
+		require.NoError(t, err)
+		var reader io.Reader
+		require.NoError(t, c.Resolve(&reader))
+		require.Same(t, buf, reader)
+		var writer io.Writer
+		require.NoError(t, c.Resolve(&writer))
+		require.Same(t, buf, writer)
+		var readers []io.Reader
+		require.NoError(t, c.Resolve(&readers))
+		require.Len(t, readers, 1)
+		var stringer fmt.Stringer
+		err = c.Resolve(&stringer)
+		require.True(t, errors.Is(err, di.ErrTypeNotExists))
+	})
+
+	t.Run("interfaces of all packages bound", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *bytes.Buffer { return &bytes.Buffer{} }, di.AsImplemented()),
+		)
+		require.NoError(t, err)
+		var stringer fmt.Stringer
+		require.NoError(t, c.Resolve(&stringer))
+		var reader io.Reader
+		require.NoError(t, c.Resolve(&reader))
+	})
+
+	t.Run("package pattern matches subpackages", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.AsImplemented("net/...")),
+			di.Provide(func(handler http.Handler) *http.Server {
+				return &http.Server{Handler: handler}
+			}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.IsType(t, &http.ServeMux{}, server.Handler)
+	})
+
+	t.Run("interface specified with as not bound twice", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&bytes.Buffer{}, di.As(new(io.Reader)), di.AsImplemented("io")),
+		)
+		require.NoError(t, err)
+		var readers []io.Reader
+		require.NoError(t, c.Resolve(&readers))
+		require.Len(t, readers, 1)
+	})
+
+	t.Run("overridden definition not bound", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&bytes.Buffer{}, di.AsImplemented("io")),
+		)
+		require.NoError(t, err)
+		buf := &bytes.Buffer{}
+		require.NoError(t, c.ProvideValue(buf, di.AsImplemented("io"), di.Override()))
+		var readers []io.Reader
+		require.NoError(t, c.Resolve(&readers))
+		require.Len(t, readers, 1)
+		require.Same(t, buf, readers[0])
+	})
+
+	t.Run("child container binds parent definitions", func(t *testing.T) {
+		parent, err := di.New(
+			di.ProvideValue(&bytes.Buffer{}, di.AsImplemented("io")),
+		)
+		require.NoError(t, err)
+		child, err := parent.NewChild()
+		require.NoError(t, err)
+		var reader io.Reader
+		require.NoError(t, child.Resolve(&reader))
+	})
+}
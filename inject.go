@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // Inject indicates that struct public fields will be injected automatically.
@@ -23,6 +24,15 @@ import (
 //		Public 	*http.Server `type:"public"` 	// *http.Server with type:public tag combination will be injected
 //		Private *http.Server `type:"private"` 	// *http.Server with type:private tag combination will be injected
 //  }
+//
+// The di tag combines tags, optionality and skip marker in one comma-separated list:
+//
+//	type Application struct {
+//		di.Inject
+//
+//		Primary *sql.DB     `di:"name=primary,optional"` // nil if *sql.DB with name primary not exists
+//		Logger  *log.Logger `di:"-"`                     // not injected, the same as di:"skip"
+//	}
 type Inject struct {
 	injectable
 }
@@ -32,6 +42,9 @@ type injectable interface {
 	isInjectable()
 }
 
+// defaultTagName is a default struct tag key of injectable fields.
+const defaultTagName = "di"
+
 type field struct {
 	rt       reflect.Type
 	tags     Tags
@@ -52,8 +65,30 @@ func canInject(t reflect.Type) bool {
 	return true
 }
 
-// parsePopulateFields parses fields of struct that can be populated.
-func parsePopulateFields(rt reflect.Type) map[int]field {
+// populateFieldsCache caches populate fields by type and tag name. Struct fields don't change
+// at runtime, so they parsed once.
+var populateFieldsCache sync.Map
+
+// populateFieldsKey is a key of populate fields cache.
+type populateFieldsKey struct {
+	rt      reflect.Type
+	tagName string
+}
+
+// parsePopulateFields returns fields of struct that can be populated. The tagName is a struct
+// tag key of field tags.
+func parsePopulateFields(rt reflect.Type, tagName string) map[int]field {
+	key := populateFieldsKey{rt: rt, tagName: tagName}
+	if cached, ok := populateFieldsCache.Load(key); ok {
+		return cached.(map[int]field)
+	}
+	fields := inspectPopulateFields(rt, tagName)
+	populateFieldsCache.Store(key, fields)
+	return fields
+}
+
+// inspectPopulateFields parses fields of struct that can be populated.
+func inspectPopulateFields(rt reflect.Type, tagName string) map[int]field {
 	if !canInject(rt) {
 		return nil
 	}
@@ -80,7 +115,7 @@ func parsePopulateFields(rt reflect.Type) map[int]field {
 		}
 		// cur - current field
 		cur := rt.Field(fi)
-		f, valid := inspectStructField(rt, cur)
+		f, valid := inspectStructField(rt, cur, tagName)
 		if !valid {
 			continue
 		}
@@ -93,8 +128,9 @@ func parsePopulateFields(rt reflect.Type) map[int]field {
 	return fields
 }
 
-// inspectStructField parses struct field
-func inspectStructField(rt reflect.Type, f reflect.StructField) (field, bool) {
+// inspectStructField parses struct field. Deprecated tagging style supported only with default
+// tag name.
+func inspectStructField(rt reflect.Type, f reflect.StructField, tagName string) (field, bool) {
 
 	result := field{
 		rt:       f.Type,
@@ -105,12 +141,14 @@ func inspectStructField(rt reflect.Type, f reflect.StructField) (field, bool) {
 		return result, true
 	}
 
-	diTag := f.Tag.Get("di")
-	if diTag != "" {
+	diTag, ok := f.Tag.Lookup(tagName)
+	if ok {
 		for _, v := range strings.Split(diTag, ",") {
 			v = strings.TrimSpace(v)
 			switch v {
-			case "skip":
+			case "":
+				// empty tag marks field as injectable without tags
+			case "skip", "-":
 				return field{}, false
 			case "optional":
 				result.optional = true
@@ -119,11 +157,13 @@ func inspectStructField(rt reflect.Type, f reflect.StructField) (field, bool) {
 				if len(kv) == 2 {
 					result.tags[kv[0]] = kv[1]
 				} else {
-					panic(fmt.Sprintf("invalid di tag: key=value got: %s", v))
+					panic(fmt.Sprintf("invalid %s tag: key=value got: %s", tagName, v))
 				}
 			}
 		}
 		return result, true
+	} else if tagName != defaultTagName {
+		return result, true
 	} else {
 		// handle the old deprecated struct tagging style.
 		result, noSkip := inspectStructFieldDeprecated(f)
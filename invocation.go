@@ -1,5 +1,9 @@
 package di
 
+import (
+	"reflect"
+)
+
 // validateInvocation validates function.
 func validateInvocation(fn function) bool {
 	if fn.NumOut() == 0 {
@@ -11,6 +15,17 @@ func validateInvocation(fn function) bool {
 	return false
 }
 
+// validateResultInvocation validates function that returns result of type rt.
+func validateResultInvocation(fn function, rt reflect.Type) bool {
+	switch fn.NumOut() {
+	case 1:
+		return fn.Out(0).AssignableTo(rt)
+	case 2:
+		return fn.Out(0).AssignableTo(rt) && isError(fn.Out(1))
+	}
+	return false
+}
+
 // parseInvocationParameters parses invocation and returns slice of nodes.
 func parseInvocationParameters(fn function, s schema) (params []*node, err error) {
 	for i := 0; i < fn.NumIn(); i++ {
@@ -0,0 +1,49 @@
+//go:build go1.23
+
+package di
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// All returns iterator over group members of type T. Members constructed lazily on iteration,
+// so breaking the loop skips construction of the rest. The iteration stops after first error.
+//
+//	for handler, err := range di.All[http.Handler](container) {
+//		if err != nil {
+//			// handle error
+//		}
+//	}
+func All[T any](c *Container, options ...ResolveOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		node, err := c.find(new([]T), options...)
+		if err != nil {
+			yield(zero, errWithStack(err))
+			return
+		}
+		group, ok := node.compiler.(*groupCompiler)
+		if !ok {
+			yield(zero, errWithStack(fmt.Errorf("iteration can be used with groups only")))
+			return
+		}
+		for i, n := range group.matched {
+			v, err := n.Value(c.schema)
+			if err != nil {
+				yield(zero, fmt.Errorf("%s with index %d failed: %w", node, i, err))
+				return
+			}
+			if !yield(convert[T](v), nil) {
+				return
+			}
+		}
+	}
+}
+
+// convert converts value into T. Value of interface type can be nil.
+func convert[T any](v reflect.Value) T {
+	result, _ := v.Interface().(T)
+	return result
+}
@@ -0,0 +1,78 @@
+//go:build go1.23
+
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestAll(t *testing.T) {
+	t.Run("iterates over group members", func(t *testing.T) {
+		mux := http.NewServeMux()
+		c, err := di.New(
+			di.ProvideValue(mux, di.As(new(http.Handler)), di.Tags{"role": "admin"}),
+			di.Provide(http.NotFoundHandler, di.Tags{"role": "public"}),
+		)
+		require.NoError(t, err)
+		var handlers []http.Handler
+		for handler, err := range di.All[http.Handler](c) {
+			require.NoError(t, err)
+			handlers = append(handlers, handler)
+		}
+		require.Len(t, handlers, 2)
+		require.Equal(t, mux, handlers[0])
+		handlers = nil
+		for handler, err := range di.All[http.Handler](c, di.Tags{"role": "public"}) {
+			require.NoError(t, err)
+			handlers = append(handlers, handler)
+		}
+		require.Len(t, handlers, 1)
+	})
+
+	t.Run("break skips construction", func(t *testing.T) {
+		var built int
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux { built++; return http.NewServeMux() }, di.Tags{"name": "first"}),
+			di.Provide(func() *http.ServeMux { built++; return http.NewServeMux() }, di.Tags{"name": "second"}),
+		)
+		require.NoError(t, err)
+		for _, err := range di.All[*http.ServeMux](c) {
+			require.NoError(t, err)
+			break
+		}
+		require.Equal(t, 1, built)
+	})
+
+	t.Run("constructor error stops iteration", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() (*http.ServeMux, error) { return nil, errors.New("mux error") }),
+			di.Provide(http.NewServeMux, di.Tags{"name": "second"}),
+		)
+		require.NoError(t, err)
+		var errs []error
+		for mux, err := range di.All[*http.ServeMux](c) {
+			require.Nil(t, mux)
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		require.EqualError(t, errs[0], "[]*http.ServeMux with index 0 failed: mux error")
+	})
+
+	t.Run("not existing group cause error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		var errs []error
+		for _, err := range di.All[*http.ServeMux](c) {
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		require.True(t, errors.Is(errs[0], di.ErrTypeNotExists))
+		require.Contains(t, errs[0].Error(), "iter_test.go:")
+	})
+}
@@ -0,0 +1,129 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// keyTag is a tag of group members that keys them in di.Keyed registry.
+const keyTag = "key"
+
+// Keyed is a registry of group members of type T keyed by value of "key" tag. Declare it as
+// constructor parameter or injectable field to collect definitions without custom glue
+// constructors. Members without key are not included, duplicate keys cause ErrAmbiguousType.
+//
+//	type Command string
+//
+//	container, err := di.New(
+//		di.Provide(NewCreateUserHandler, di.As(new(CommandHandler)), di.Tags{"key": "create-user"}),
+//		di.Provide(NewDeleteUserHandler, di.As(new(CommandHandler)), di.Tags{"key": "delete-user"}),
+//		di.Provide(func(handlers di.Keyed[Command, CommandHandler]) *Bus {
+//			return &Bus{handlers: handlers}
+//		}),
+//	)
+type Keyed[K ~string, T any] struct {
+	items map[K]T
+}
+
+// Get returns member by key.
+func (r Keyed[K, T]) Get(key K) (T, bool) {
+	item, ok := r.items[key]
+	return item, ok
+}
+
+// Keys returns sorted keys of members.
+func (r Keyed[K, T]) Keys() []K {
+	keys := make([]K, 0, len(r.items))
+	for key := range r.items {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// Len returns count of members.
+func (r Keyed[K, T]) Len() int {
+	return len(r.items)
+}
+
+// Map returns copy of members keyed by key.
+func (r Keyed[K, T]) Map() map[K]T {
+	items := make(map[K]T, len(r.items))
+	for key, item := range r.items {
+		items[key] = item
+	}
+	return items
+}
+
+// mapType returns reflect.Type of members map.
+func (Keyed[K, T]) mapType() reflect.Type {
+	return reflect.TypeOf(map[K]T{})
+}
+
+// with returns registry of members map rv.
+func (Keyed[K, T]) with(rv reflect.Value) interface{} {
+	return Keyed[K, T]{items: rv.Interface().(map[K]T)}
+}
+
+// keyedValue is a common interface of Keyed[K, T] types.
+type keyedValue interface {
+	mapType() reflect.Type
+	with(rv reflect.Value) interface{}
+}
+
+// keyedValueType is a reflect.Type of keyedValue.
+var keyedValueType = reflect.TypeOf(new(keyedValue)).Elem()
+
+// isKeyed checks that t is a Keyed[K, T] type.
+func isKeyed(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Implements(keyedValueType)
+}
+
+// keyedCompiler compiles Keyed[K, T] registry of members map.
+type keyedCompiler struct {
+	*groupCompiler
+	keyed reflect.Type
+}
+
+func (c keyedCompiler) compile(dependencies []reflect.Value, s schema) (reflect.Value, error) {
+	rv, err := c.groupCompiler.compile(dependencies, s)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(reflect.Zero(c.keyed).Interface().(keyedValue).with(rv)), nil
+}
+
+// keyed creates node of Keyed[K, T] with group members keyed by key tag.
+func (s *defaultSchema) keyed(t reflect.Type, q query) (*node, error) {
+	mt := reflect.Zero(t).Interface().(keyedValue).mapType()
+	group, ok := s.list(mt.Elem())
+	if !ok {
+		return nil, resolveError(t, q, fmt.Errorf("type %s%s %w", t, q, ErrTypeNotExists))
+	}
+	var matched []*node
+	// keys are not nil, so group compiler creates map even without members
+	keys := []string{}
+	for _, n := range q.match(group) {
+		key, ok := n.tags[keyTag]
+		if !ok {
+			continue
+		}
+		for _, cur := range keys {
+			if cur == key {
+				return nil, resolveError(t, q, fmt.Errorf("%w of %s with key %s", ErrAmbiguousType, mt.Elem(), key))
+			}
+		}
+		matched = append(matched, n)
+		keys = append(keys, key)
+	}
+	node := &node{
+		compiler: keyedCompiler{groupCompiler: newNamedGroupCompiler(mt, matched, keys), keyed: t},
+		rt:       t,
+		tags:     q.tags,
+		rv:       new(reflect.Value),
+	}
+	return node, nil
+}
@@ -0,0 +1,89 @@
+package di_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestKeyed(t *testing.T) {
+	type Route string
+
+	t.Run("constructor parameter collects keyed members", func(t *testing.T) {
+		type Router struct {
+			handlers di.Keyed[Route, http.Handler]
+		}
+		users := http.NewServeMux()
+		orders := http.NewServeMux()
+		c, err := di.New(
+			di.ProvideValue(users, di.As(new(http.Handler)), di.Tags{"key": "/users"}),
+			di.ProvideValue(orders, di.As(new(http.Handler)), di.Tags{"key": "/orders"}),
+			di.Provide(http.NotFoundHandler),
+			di.Provide(func(handlers di.Keyed[Route, http.Handler]) *Router {
+				return &Router{handlers: handlers}
+			}),
+		)
+		require.NoError(t, err)
+		var router *Router
+		require.NoError(t, c.Resolve(&router))
+		require.Equal(t, 2, router.handlers.Len())
+		require.Equal(t, []Route{"/orders", "/users"}, router.handlers.Keys())
+		handler, ok := router.handlers.Get("/users")
+		require.True(t, ok)
+		require.Same(t, users, handler)
+		_, ok = router.handlers.Get("/unknown")
+		require.False(t, ok)
+		require.Len(t, router.handlers.Map(), 2)
+	})
+
+	t.Run("injectable field", func(t *testing.T) {
+		type Servers struct {
+			di.Inject
+			All di.Keyed[string, *http.Server]
+		}
+		c, err := di.New(
+			di.ProvideValue(&http.Server{Addr: ":80"}, di.Tags{"key": "public"}),
+			di.ProvideValue(&http.Server{Addr: ":8080"}, di.Tags{"key": "private"}),
+		)
+		require.NoError(t, err)
+		var servers Servers
+		require.NoError(t, c.Resolve(&servers))
+		server, ok := servers.All.Get("private")
+		require.True(t, ok)
+		require.Equal(t, ":8080", server.Addr)
+	})
+
+	t.Run("members without key are not included", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}),
+		)
+		require.NoError(t, err)
+		var servers di.Keyed[string, *http.Server]
+		require.NoError(t, c.Resolve(&servers))
+		require.Equal(t, 0, servers.Len())
+	})
+
+	t.Run("duplicate key causes error", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}, di.Tags{"key": "public"}),
+			di.ProvideValue(&http.Server{}, di.Tags{"key": "public"}),
+		)
+		require.NoError(t, err)
+		var servers di.Keyed[string, *http.Server]
+		err = c.Resolve(&servers)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "multiple definitions of *http.Server with key public")
+	})
+
+	t.Run("not existing type causes error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		var servers di.Keyed[string, *http.Server]
+		err = c.Resolve(&servers)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not exists in the container")
+	})
+}
@@ -0,0 +1,121 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Lazy is a dependency of type T which construction deferred until Get() call. Declare it as
+// constructor parameter or injectable field to instantiate expensive dependency only on code
+// paths that need it. Tags of field applied to T.
+//
+//	func NewHandler(reports di.Lazy[*ReportGenerator]) *Handler {
+//		return &Handler{reports: reports}
+//	}
+//
+//	func (h *Handler) Report(w http.ResponseWriter, r *http.Request) {
+//		generator, err := h.reports.Get()
+//		if err != nil {
+//			// handle error
+//		}
+//		// ...
+//	}
+//
+// By default, lazy dependency breaks dependency cycles and dependencies of T checked on first
+// Get() call, so Get() must not be called from constructor of T dependencies. See
+// di.WithCycleStrategy().
+type Lazy[T any] struct {
+	lazy *lazy
+}
+
+// Get returns value of T. The value built on first call and follows lifetime of its definition.
+func (l Lazy[T]) Get() (T, error) {
+	var zero T
+	if l.lazy == nil {
+		return zero, errors.New("lazy value is not initialized by container")
+	}
+	rv, err := l.lazy.get()
+	if err != nil {
+		return zero, err
+	}
+	// value of interface type can be nil
+	value, _ := rv.Interface().(T)
+	return value, nil
+}
+
+// lazyType returns reflect.Type of lazy value.
+func (Lazy[T]) lazyType() reflect.Type {
+	return reflect.TypeOf(new(T)).Elem()
+}
+
+// with returns lazy value that uses l to get value.
+func (Lazy[T]) with(l *lazy) interface{} {
+	return Lazy[T]{lazy: l}
+}
+
+// lazyValue is a common interface of Lazy[T] types.
+type lazyValue interface {
+	lazyType() reflect.Type
+	with(l *lazy) interface{}
+}
+
+// lazyValueType is a reflect.Type of lazyValue.
+var lazyValueType = reflect.TypeOf(new(lazyValue)).Elem()
+
+// isLazy checks that t is a Lazy[T] type.
+func isLazy(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Implements(lazyValueType)
+}
+
+// lazy gets value of target node.
+type lazy struct {
+	target *node
+	schema schema
+}
+
+func (l *lazy) get() (reflect.Value, error) {
+	if err := l.schema.scope().prepare(l.target); err != nil {
+		return reflect.Value{}, err
+	}
+	rv, err := l.target.Value(l.schema)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%s: %w", l.target, err)
+	}
+	return rv, nil
+}
+
+// lazyCompiler compiles Lazy[T] of target node.
+type lazyCompiler struct {
+	rt     reflect.Type
+	target *node
+}
+
+func (c lazyCompiler) deps(s schema) ([]*node, error) {
+	// target is not a dependency, it built on Get()
+	return nil, nil
+}
+
+func (c lazyCompiler) compile(dependencies []reflect.Value, s schema) (reflect.Value, error) {
+	value := reflect.Zero(c.rt).Interface().(lazyValue).with(&lazy{target: c.target, schema: s})
+	return reflect.ValueOf(value), nil
+}
+
+func (c lazyCompiler) deferred() *node {
+	return c.target
+}
+
+// lazy creates node of Lazy[T] with target node of T that matches query.
+func (s *defaultSchema) lazy(t reflect.Type, q query) (*node, error) {
+	target, err := s.search(reflect.Zero(t).Interface().(lazyValue).lazyType(), q)
+	if err != nil {
+		return nil, err
+	}
+	node := &node{
+		compiler: lazyCompiler{rt: t, target: target},
+		rt:       t,
+		tags:     q.tags,
+		rv:       new(reflect.Value),
+	}
+	return node, nil
+}
@@ -0,0 +1,96 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestLazy(t *testing.T) {
+	t.Run("constructor parameter built on get", func(t *testing.T) {
+		var built int
+		var lazy di.Lazy[*http.ServeMux]
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux {
+				built++
+				return http.NewServeMux()
+			}),
+			di.Provide(func(mux di.Lazy[*http.ServeMux]) *http.Server {
+				lazy = mux
+				return &http.Server{}
+			}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, 0, built)
+		mux1, err := lazy.Get()
+		require.NoError(t, err)
+		mux2, err := lazy.Get()
+		require.NoError(t, err)
+		require.Same(t, mux1, mux2)
+		require.Equal(t, 1, built)
+	})
+
+	t.Run("injectable field with tags", func(t *testing.T) {
+		type Servers struct {
+			di.Inject
+			Public di.Lazy[*http.Server] `di:"name=public"`
+		}
+		public := &http.Server{Addr: ":80"}
+		c, err := di.New(
+			di.ProvideValue(public, di.Tags{"name": "public"}),
+			di.ProvideValue(&http.Server{Addr: ":8080"}, di.Tags{"name": "private"}),
+		)
+		require.NoError(t, err)
+		var servers Servers
+		require.NoError(t, c.Resolve(&servers))
+		server, err := servers.Public.Get()
+		require.NoError(t, err)
+		require.Same(t, public, server)
+	})
+
+	t.Run("resolve lazy of not existing type causes error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		var lazy di.Lazy[*http.Server]
+		err = c.Resolve(&lazy)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type *http.Server not exists in the container")
+	})
+
+	t.Run("lazy breaks dependency cycle", func(t *testing.T) {
+		type A struct{ client *http.Client }
+		c, err := di.New(
+			di.Provide(func(lazy di.Lazy[*A]) *http.Client { return &http.Client{} }),
+			di.Provide(func(client *http.Client) *A { return &A{client: client} }),
+		)
+		require.NoError(t, err)
+		var lazy di.Lazy[*A]
+		require.NoError(t, c.Resolve(&lazy))
+		a, err := lazy.Get()
+		require.NoError(t, err)
+		require.NotNil(t, a.client)
+	})
+
+	t.Run("construction error returned by get", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() (*http.Server, error) { return nil, errors.New("server error") }),
+		)
+		require.NoError(t, err)
+		var lazy di.Lazy[*http.Server]
+		require.NoError(t, c.Resolve(&lazy))
+		_, err = lazy.Get()
+		require.EqualError(t, err, "*http.Server: server error")
+	})
+
+	t.Run("get of zero lazy causes error", func(t *testing.T) {
+		var lazy di.Lazy[*http.Server]
+		_, err := lazy.Get()
+		require.EqualError(t, err, "lazy value is not initialized by container")
+	})
+}
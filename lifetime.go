@@ -0,0 +1,153 @@
+package di
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Lifetime specifies how long resolved instance lives. See di.WithLifetime().
+type Lifetime int
+
+const (
+	// Singleton is a lifetime of instance that created once and shared by container and
+	// its children. It is a default lifetime.
+	Singleton Lifetime = iota
+	// Scoped is a lifetime of instance that created once per container. Each child container
+	// created by Container.NewChild() has its own instance.
+	Scoped
+	// Transient is a lifetime of instance that created on each resolve.
+	Transient
+	// Cached is a lifetime of instance that cached by strategy of di.WithCache().
+	Cached
+)
+
+// String is a string representation of lifetime.
+func (l Lifetime) String() string {
+	switch l {
+	case Singleton:
+		return "singleton"
+	case Scoped:
+		return "scoped"
+	case Transient:
+		return "transient"
+	case Cached:
+		return "cached"
+	}
+	return "unknown"
+}
+
+// MarshalText encodes lifetime as its string representation.
+func (l Lifetime) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// WithLifetime returns provide option that specifies lifetime of provided type instances.
+//
+//	container, err := di.New(
+//		di.Provide(NewRequestLogger, di.WithLifetime(di.Scoped)),
+//	)
+func WithLifetime(lifetime Lifetime) ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.Lifetime = lifetime
+	})
+}
+
+// Cache is a caching strategy of definition instances. The instance built on cache miss and
+// stored into cache. Implementations must be safe for concurrent use if container resolves
+// types concurrently.
+type Cache interface {
+	// Load returns cached instance.
+	Load() (Value, bool)
+	// Store caches instance.
+	Store(v Value)
+}
+
+// WithCache returns provide option that caches instances of provided type by strategy. See
+// di.NewSingletonCache() and di.NewKeyedCache().
+//
+//	container, err := di.New(
+//		di.Provide(NewTenantDB, di.WithCache(di.NewKeyedCache(currentTenant))),
+//	)
+func WithCache(cache Cache) ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.Lifetime = Cached
+		params.Cache = cache
+	})
+}
+
+// singletonCache caches one instance.
+type singletonCache struct {
+	mu    sync.Mutex
+	value Value
+	ok    bool
+}
+
+// NewSingletonCache creates cache that holds one instance. Unlike di.Singleton lifetime the
+// cache can be shared between definitions or containers.
+func NewSingletonCache() Cache {
+	return &singletonCache{}
+}
+
+func (c *singletonCache) Load() (Value, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value, c.ok
+}
+
+func (c *singletonCache) Store(v Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value, c.ok = v, true
+}
+
+// keyedCache caches instance per key.
+type keyedCache struct {
+	mu     sync.Mutex
+	key    func() (string, bool)
+	values map[string]Value
+}
+
+// NewKeyedCache creates cache that holds instance per key. The key function returns key of
+// current scope, e.g. tenant or job identifier kept in goroutine local state. If key function
+// returns false instance is not cached.
+func NewKeyedCache(key func() (string, bool)) Cache {
+	return &keyedCache{
+		key:    key,
+		values: map[string]Value{},
+	}
+}
+
+func (c *keyedCache) Load() (Value, bool) {
+	key, ok := c.key()
+	if !ok {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[key]
+	return v, ok
+}
+
+func (c *keyedCache) Store(v Value) {
+	key, ok := c.key()
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = v
+}
+
+// scopedValue returns value of node cached in scope.
+func (s *defaultSchema) scopedValue(n *node) (reflect.Value, bool) {
+	rv, ok := s.values[n]
+	return rv, ok
+}
+
+// storeScopedValue caches value of node in scope.
+func (s *defaultSchema) storeScopedValue(n *node, rv reflect.Value) {
+	if s.values == nil {
+		s.values = map[*node]reflect.Value{}
+	}
+	s.values[n] = rv
+}
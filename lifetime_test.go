@@ -0,0 +1,142 @@
+package di_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestWithLifetime(t *testing.T) {
+	t.Run("singleton shared by container and children", func(t *testing.T) {
+		parent, err := di.New(
+			di.Provide(http.NewServeMux, di.WithLifetime(di.Singleton)),
+		)
+		require.NoError(t, err)
+		child, err := parent.NewChild()
+		require.NoError(t, err)
+		var mux1, mux2 *http.ServeMux
+		require.NoError(t, parent.Resolve(&mux1))
+		require.NoError(t, child.Resolve(&mux2))
+		require.Equal(t, fmt.Sprintf("%p", mux1), fmt.Sprintf("%p", mux2))
+	})
+
+	t.Run("scoped created once per container", func(t *testing.T) {
+		created := 0
+		parent, err := di.New(
+			di.Provide(func() *http.ServeMux {
+				created++
+				return &http.ServeMux{}
+			}, di.WithLifetime(di.Scoped), di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		first, err := parent.NewChild()
+		require.NoError(t, err)
+		second, err := parent.NewChild()
+		require.NoError(t, err)
+		var mux1, mux2, mux3 *http.ServeMux
+		require.NoError(t, first.Resolve(&mux1))
+		require.NoError(t, first.Resolve(&mux2))
+		require.Equal(t, fmt.Sprintf("%p", mux1), fmt.Sprintf("%p", mux2))
+		var handler http.Handler
+		require.NoError(t, first.Resolve(&handler))
+		require.Equal(t, fmt.Sprintf("%p", mux1), fmt.Sprintf("%p", handler))
+		require.NoError(t, second.Resolve(&mux3))
+		require.False(t, mux1 == mux3)
+		require.Equal(t, 2, created)
+	})
+
+	t.Run("scoped cleanup belongs to scope", func(t *testing.T) {
+		cleaned := 0
+		parent, err := di.New(
+			di.Provide(func() (*http.ServeMux, func()) {
+				return &http.ServeMux{}, func() { cleaned++ }
+			}, di.WithLifetime(di.Scoped)),
+		)
+		require.NoError(t, err)
+		child, err := parent.NewChild()
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		require.NoError(t, child.Resolve(&mux))
+		parent.Cleanup()
+		require.Equal(t, 0, cleaned)
+		child.Cleanup()
+		require.Equal(t, 1, cleaned)
+	})
+
+	t.Run("transient created on each resolve", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.WithLifetime(di.Transient)),
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }),
+		)
+		require.NoError(t, err)
+		var mux1, mux2 *http.ServeMux
+		require.NoError(t, c.Resolve(&mux1))
+		require.NoError(t, c.Resolve(&mux2))
+		require.False(t, mux1 == mux2)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.False(t, server.Handler == mux1)
+		require.False(t, server.Handler == mux2)
+	})
+
+	t.Run("singleton cache shared between containers", func(t *testing.T) {
+		created := 0
+		cache := di.NewSingletonCache()
+		ctor := func() *http.ServeMux {
+			created++
+			return &http.ServeMux{}
+		}
+		first, err := di.New(di.Provide(ctor, di.WithCache(cache)))
+		require.NoError(t, err)
+		second, err := di.New(di.Provide(ctor, di.WithCache(cache)))
+		require.NoError(t, err)
+		var mux1, mux2 *http.ServeMux
+		require.NoError(t, first.Resolve(&mux1))
+		require.NoError(t, second.Resolve(&mux2))
+		require.True(t, mux1 == mux2)
+		require.Equal(t, 1, created)
+	})
+
+	t.Run("keyed cache holds instance per key", func(t *testing.T) {
+		key, cached := "first", true
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler)), di.WithCache(di.NewKeyedCache(func() (string, bool) {
+				return key, cached
+			}))),
+		)
+		require.NoError(t, err)
+		var mux1, mux2, mux3, mux4 *http.ServeMux
+		require.NoError(t, c.Resolve(&mux1))
+		var handler http.Handler
+		require.NoError(t, c.Resolve(&handler))
+		require.True(t, mux1 == handler)
+		key = "second"
+		require.NoError(t, c.Resolve(&mux2))
+		require.False(t, mux1 == mux2)
+		key = "first"
+		require.NoError(t, c.Resolve(&mux3))
+		require.True(t, mux1 == mux3)
+		cached = false
+		require.NoError(t, c.Resolve(&mux4))
+		require.False(t, mux1 == mux4)
+	})
+
+	t.Run("cached lifetime without cache cause error", func(t *testing.T) {
+		_, err := di.New(
+			di.Provide(http.NewServeMux, di.WithLifetime(di.Cached)),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "*http.ServeMux: cached lifetime requires cache, use di.WithCache()")
+	})
+
+	t.Run("lifetime string", func(t *testing.T) {
+		require.Equal(t, "singleton", di.Singleton.String())
+		require.Equal(t, "scoped", di.Scoped.String())
+		require.Equal(t, "transient", di.Transient.String())
+		require.Equal(t, "cached", di.Cached.String())
+	})
+}
@@ -0,0 +1,131 @@
+package di
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ByteSize is a size in bytes. Its literal is a number with optional unit: B, KB, MB, GB, TB
+// with decimal multiples or KiB, MiB, GiB, TiB with binary ones, e.g. "512KiB" or "10 MB".
+type ByteSize int64
+
+// byteUnits is a multiples of byte size units.
+var byteUnits = map[string]ByteSize{
+	"":    1,
+	"B":   1,
+	"KB":  1000,
+	"MB":  1000 * 1000,
+	"GB":  1000 * 1000 * 1000,
+	"TB":  1000 * 1000 * 1000 * 1000,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// ParseByteSize parses byte size literal.
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i == -1 {
+		i = len(s)
+	}
+	unit, ok := byteUnits[strings.TrimSpace(s[i:])]
+	if !ok {
+		return 0, fmt.Errorf("unknown byte size unit %q", strings.TrimSpace(s[i:]))
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	return ByteSize(n * float64(unit)), nil
+}
+
+// literal is a definition of value parsed from string.
+type literal struct {
+	rt   reflect.Type
+	text string
+}
+
+// Literal returns container option that provides value of type T with name parsed from text.
+// The text parsed on provide and invalid text causes error of container creation. Supported
+// types are time.Duration, di.ByteSize, url.URL and *url.URL with absolute URL, and types
+// which pointer implements encoding.TextUnmarshaler.
+//
+//	container, err := di.New(
+//		di.Literal[time.Duration](os.Getenv("TIMEOUT"), "timeout"),
+//		di.Literal[di.ByteSize]("10MiB", "max-body"),
+//		di.Literal[*url.URL]("https://api.example.com", "api"),
+//	)
+func Literal[T any](text string, name string, options ...ProvideOption) Option {
+	frame := stacktrace(0)
+	return option(func(c *diopts) {
+		c.provides = append(c.provides, provideOptions{
+			frame,
+			&literal{rt: reflect.TypeOf(new(T)).Elem(), text: text},
+			append([]ProvideOption{Tags{"name": name}}, options...),
+		})
+	})
+}
+
+// newLiteralNode creates node of value parsed from literal.
+func newLiteralNode(l *literal) (*node, error) {
+	rv, err := parseLiteral(l.rt, l.text)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q as %s: %w", l.text, l.rt, err)
+	}
+	return &node{
+		compiler: valueCompiler{rv: rv},
+		rt:       l.rt,
+		tags:     Tags{},
+		rv:       new(reflect.Value),
+	}, nil
+}
+
+// textUnmarshalerType is a reflect.Type of encoding.TextUnmarshaler.
+var textUnmarshalerType = reflect.TypeOf(new(encoding.TextUnmarshaler)).Elem()
+
+// parseLiteral parses text as value of type rt.
+func parseLiteral(rt reflect.Type, text string) (reflect.Value, error) {
+	switch rt {
+	case reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(d), nil
+	case reflect.TypeOf(ByteSize(0)):
+		size, err := ParseByteSize(text)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(size), nil
+	case reflect.TypeOf(url.URL{}), reflect.TypeOf(&url.URL{}):
+		u, err := url.Parse(text)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !u.IsAbs() {
+			return reflect.Value{}, fmt.Errorf("url is not absolute")
+		}
+		if rt.Kind() == reflect.Ptr {
+			return reflect.ValueOf(u), nil
+		}
+		return reflect.ValueOf(*u), nil
+	}
+	if reflect.PtrTo(rt).Implements(textUnmarshalerType) {
+		rv := reflect.New(rt)
+		if err := rv.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			return reflect.Value{}, err
+		}
+		return rv.Elem(), nil
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported literal type")
+}
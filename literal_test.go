@@ -0,0 +1,81 @@
+package di_test
+
+import (
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestLiteral(t *testing.T) {
+	t.Run("literals parsed", func(t *testing.T) {
+		c, err := di.New(
+			di.Literal[time.Duration]("1m30s", "timeout"),
+			di.Literal[di.ByteSize]("10MiB", "max-body"),
+			di.Literal[*url.URL]("https://api.example.com/v1", "api"),
+			di.Literal[url.URL]("https://example.com", "site"),
+			di.Literal[net.IP]("127.0.0.1", "host"),
+		)
+		require.NoError(t, err)
+		timeout, err := di.ResolveAs[time.Duration](c, di.Name("timeout"))
+		require.NoError(t, err)
+		require.Equal(t, 90*time.Second, timeout)
+		size, err := di.ResolveAs[di.ByteSize](c, di.Name("max-body"))
+		require.NoError(t, err)
+		require.Equal(t, di.ByteSize(10<<20), size)
+		api, err := di.ResolveAs[*url.URL](c, di.Name("api"))
+		require.NoError(t, err)
+		require.Equal(t, "api.example.com", api.Host)
+		site, err := di.ResolveAs[url.URL](c, di.Name("site"))
+		require.NoError(t, err)
+		require.Equal(t, "https", site.Scheme)
+		host, err := di.ResolveAs[net.IP](c, di.Name("host"))
+		require.NoError(t, err)
+		require.True(t, host.IsLoopback())
+	})
+
+	t.Run("invalid literal causes error on provide", func(t *testing.T) {
+		for _, opt := range []di.Option{
+			di.Literal[time.Duration]("5 seconds", "timeout"),
+			di.Literal[di.ByteSize]("5 XB", "size"),
+			di.Literal[*url.URL]("/relative", "api"),
+			di.Literal[net.IP]("localhost", "host"),
+			di.Literal[chan int]("1", "unsupported"),
+		} {
+			_, err := di.New(opt)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "literal_test.go:")
+		}
+		_, err := di.New(di.Literal[*url.URL]("/relative", "api"))
+		require.Contains(t, err.Error(), `parse "/relative" as *url.URL: url is not absolute`)
+	})
+}
+
+func TestParseByteSize(t *testing.T) {
+	for text, expected := range map[string]di.ByteSize{
+		"512":     512,
+		"512B":    512,
+		"1KB":     1000,
+		"1.5 MB":  1500000,
+		"2GiB":    2 << 30,
+		" 1TiB ":  1 << 40,
+		"0.5KiB":  512,
+		"100 GB":  100 * 1000 * 1000 * 1000,
+		"3 MiB":   3 << 20,
+		"64 KiB":  64 << 10,
+		"10TB":    10 * 1000 * 1000 * 1000 * 1000,
+		"1024KiB": 1 << 20,
+	} {
+		size, err := di.ParseByteSize(text)
+		require.NoError(t, err, text)
+		require.Equal(t, expected, size, text)
+	}
+	for _, text := range []string{"", "MB", "-1KB", "1XB", "1.2.3MB"} {
+		_, err := di.ParseByteSize(text)
+		require.Error(t, err, text)
+	}
+}
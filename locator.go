@@ -0,0 +1,82 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Locator is a string keyed facade of container. It resolves definitions by their names and
+// useful for template engines and scripting layers that can work only with string keys.
+type Locator struct {
+	c *Container
+}
+
+// Locator returns string keyed facade of container backed by named definitions.
+//
+//	v, err := container.Locator().Get("public")
+//	if err != nil {
+//		// handle error
+//	}
+func (c *Container) Locator() Locator {
+	return Locator{c: c}
+}
+
+// Get resolves definition with name. Name must identify definition type uniquely.
+func (l Locator) Get(name string) (interface{}, error) {
+	rt, err := l.lookup(name)
+	if err != nil {
+		return nil, errWithStack(err)
+	}
+	ptr := reflect.New(rt)
+	if err := l.c.resolve(ptr.Interface(), Name(name)); err != nil {
+		return nil, errWithStack(err)
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+// Names returns sorted list of definition names.
+func (l Locator) Names() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, n := range l.c.schema.all() {
+		name, ok := n.tags["name"]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup finds type of definition with name. Interfaces registered with di.As() are skipped,
+// so they don't make name ambiguous.
+func (l Locator) lookup(name string) (reflect.Type, error) {
+	var types []reflect.Type
+	seen := map[reflect.Type]bool{}
+	for _, n := range l.c.schema.all() {
+		if n.origin != nil || seen[n.rt] {
+			continue
+		}
+		if v, ok := n.tags["name"]; !ok || v != name {
+			continue
+		}
+		seen[n.rt] = true
+		types = append(types, n.rt)
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("definition %s %w", name, ErrTypeNotExists)
+	}
+	if len(types) > 1 {
+		names := make([]string, 0, len(types))
+		for _, t := range types {
+			names = append(names, t.String())
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("name %s is ambiguous, it used by %s", name, strings.Join(names, ", "))
+	}
+	return types[0], nil
+}
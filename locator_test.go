@@ -0,0 +1,51 @@
+package di_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestLocator(t *testing.T) {
+	t.Run("get named definition", func(t *testing.T) {
+		public := &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(public, di.Tags{"name": "public"}),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "private"}),
+			di.Provide(http.NewServeMux, di.Tags{"name": "router"}, di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		locator := c.Locator()
+		v, err := locator.Get("public")
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%p", public), fmt.Sprintf("%p", v))
+		v, err = locator.Get("router")
+		require.NoError(t, err)
+		require.IsType(t, &http.ServeMux{}, v)
+		require.Equal(t, []string{"private", "public", "router"}, locator.Names())
+	})
+
+	t.Run("get not existing name cause error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		_, err = c.Locator().Get("public")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "locator_test.go:")
+		require.Contains(t, err.Error(), "definition public not exists in the container")
+	})
+
+	t.Run("get ambiguous name cause error", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "public"}),
+			di.Provide(http.NewServeMux, di.Tags{"name": "public"}),
+		)
+		require.NoError(t, err)
+		_, err = c.Locator().Get("public")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "name public is ambiguous, it used by *http.ServeMux, *http.Server")
+	})
+}
@@ -0,0 +1,82 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ResolveFunc resolves value of definition.
+type ResolveFunc func(def NodeInfo) (Value, error)
+
+// Middleware is a value middleware that wraps obtaining of definition value. It can inspect
+// definition, call next to get value, replace value or error. Authorization and caching are not
+// middleware stages: dependencies authorized before middlewares called and next function returns
+// cached value for definitions that already built, so middleware called on each resolution.
+//
+//	func Tracing(next di.ResolveFunc) di.ResolveFunc {
+//		return func(def di.NodeInfo) (di.Value, error) {
+//			start := time.Now()
+//			v, err := next(def)
+//			log.Printf("resolve %s: %s", def.Type, time.Since(start))
+//			return v, err
+//		}
+//	}
+type Middleware func(next ResolveFunc) ResolveFunc
+
+// Use returns container option that adds value middlewares. The first middleware is the
+// outermost. Child containers inherit middlewares of container.
+//
+//	container, err := di.New(
+//		di.Use(Tracing, Metrics),
+//	)
+func Use(middlewares ...Middleware) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.schema.middlewares = append(c.schema.middlewares, middlewares...)
+		})
+	})
+}
+
+// resolve resolves value of node through middlewares of schema. Node info built only if
+// middlewares installed.
+func (s *defaultSchema) resolve(n *node, value func() (reflect.Value, error)) (reflect.Value, error) {
+	if len(s.middlewares) == 0 {
+		return value()
+	}
+	resolve := ResolveFunc(func(def NodeInfo) (Value, error) {
+		rv, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return rv.Interface(), nil
+	})
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		resolve = s.middlewares[i](resolve)
+	}
+	v, err := resolve(n.info())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	rv, err := valueOf(n.rt, v)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("middleware returned %w", err)
+	}
+	return rv, nil
+}
+
+// valueOf converts v to value of type rt.
+func valueOf(rt reflect.Type, v Value) (reflect.Value, error) {
+	if v == nil {
+		return reflect.Zero(rt), nil
+	}
+	rv := reflect.ValueOf(v)
+	if !rv.Type().AssignableTo(rt) {
+		return reflect.Value{}, fmt.Errorf("%s that not assignable to %s", rv.Type(), rt)
+	}
+	if rv.Type() != rt {
+		converted := reflect.New(rt).Elem()
+		converted.Set(rv)
+		rv = converted
+	}
+	return rv, nil
+}
@@ -0,0 +1,112 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestUse(t *testing.T) {
+	t.Run("middlewares wrap resolution in order", func(t *testing.T) {
+		var calls []string
+		trace := func(name string) di.Middleware {
+			return func(next di.ResolveFunc) di.ResolveFunc {
+				return func(def di.NodeInfo) (di.Value, error) {
+					calls = append(calls, name+" "+def.Type.String())
+					return next(def)
+				}
+			}
+		}
+		c, err := di.New(
+			di.Use(trace("first"), trace("second")),
+			di.Provide(http.NewServeMux),
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, []string{
+			"first *http.Server",
+			"second *http.Server",
+			"first *http.ServeMux",
+			"second *http.ServeMux",
+		}, calls)
+	})
+
+	t.Run("middleware replaces value", func(t *testing.T) {
+		replaced := &http.ServeMux{}
+		c, err := di.New(
+			di.Use(func(next di.ResolveFunc) di.ResolveFunc {
+				return func(def di.NodeInfo) (di.Value, error) {
+					if def.Type == reflect.TypeOf(new(http.Handler)).Elem() {
+						return replaced, nil
+					}
+					return next(def)
+				}
+			}),
+			di.Provide(http.NewServeMux, di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		require.NoError(t, c.Resolve(&handler))
+		require.True(t, handler == replaced)
+		var mux *http.ServeMux
+		require.NoError(t, c.Resolve(&mux))
+		require.False(t, mux == replaced)
+	})
+
+	t.Run("middleware error", func(t *testing.T) {
+		c, err := di.New(
+			di.Use(func(next di.ResolveFunc) di.ResolveFunc {
+				return func(def di.NodeInfo) (di.Value, error) {
+					return nil, errors.New("middleware error")
+				}
+			}),
+			di.Provide(http.NewServeMux),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		err = c.Resolve(&mux)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "middleware error")
+	})
+
+	t.Run("middleware returns not assignable value", func(t *testing.T) {
+		c, err := di.New(
+			di.Use(func(next di.ResolveFunc) di.ResolveFunc {
+				return func(def di.NodeInfo) (di.Value, error) {
+					return "string", nil
+				}
+			}),
+			di.Provide(http.NewServeMux),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		err = c.Resolve(&mux)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "middleware returned string that not assignable to *http.ServeMux")
+	})
+
+	t.Run("child inherits middlewares", func(t *testing.T) {
+		resolved := 0
+		parent, err := di.New(
+			di.Use(func(next di.ResolveFunc) di.ResolveFunc {
+				return func(def di.NodeInfo) (di.Value, error) {
+					resolved++
+					return next(def)
+				}
+			}),
+		)
+		require.NoError(t, err)
+		child, err := parent.NewChild(di.Provide(http.NewServeMux))
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		require.NoError(t, child.Resolve(&mux))
+		require.Equal(t, 1, resolved)
+	})
+}
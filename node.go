@@ -3,17 +3,18 @@ package di
 import (
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // newConstructorNode
 func newConstructorNode(ctor interface{}) (*node, error) {
 	f, valid := inspectFunction(ctor)
 	if !valid {
-		return nil, fmt.Errorf("invalid constructor signature, got %s", reflect.TypeOf(ctor))
+		return nil, fmt.Errorf("%w, got %s", ErrInvalidConstructor, reflect.TypeOf(ctor))
 	}
 	cmp, ok := newConstructorCompiler(f)
 	if !ok {
-		return nil, fmt.Errorf("invalid constructor signature, got %s", f.Type)
+		return nil, fmt.Errorf("%w, got %s", ErrInvalidConstructor, f.Type)
 	}
 	// result type
 	rt := f.Out(0)
@@ -27,7 +28,7 @@ func newConstructorNode(ctor interface{}) (*node, error) {
 		if !ok {
 			return nil, fmt.Errorf("tags usage error: need to embed di.Tags without field name")
 		}
-		field, ok := inspectStructField(tmp, f)
+		field, ok := inspectStructField(tmp, f, defaultTagName)
 		if ok {
 			tags = field.tags
 		}
@@ -50,6 +51,75 @@ type node struct {
 	rv *reflect.Value
 	// decorators
 	decorators []Decorator
+	// frame is a location where node was provided
+	frame callerFrame
+	// interfaces registered with di.As()
+	interfaces []reflect.Type
+	// dependencies declared by node, captured on provide
+	dependencies []TypeRef
+	// origin is a node that registered this node as its interface
+	origin *node
+	// implicit is true for nodes that was created by container itself
+	implicit bool
+	// owner is a schema where node was registered
+	owner *defaultSchema
+	// namespace is a qualifier of node, dependencies with the same qualifier preferred
+	namespace string
+	// cacheError is true if construction error must be returned on next resolves
+	cacheError bool
+	// lifetime of node instances
+	lifetime Lifetime
+	// cache is a caching strategy of cached lifetime
+	cache Cache
+	// cleanups of singleton instance
+	cleanups []func()
+	// sensitive is true if node must be redacted in exports
+	sensitive bool
+	// order is a position of node in groups
+	order int
+	// sharedMutable is true if node is intentionally shared mutable state
+	sharedMutable bool
+	// setters called after construction
+	setters []setter
+	// tagged is true if fields with tag injected even without di.Inject
+	tagged bool
+	// byDefault is true if node used only when no other nodes match
+	byDefault bool
+	// primary is true if node preferred when many nodes match
+	primary bool
+	// implemented is a package patterns of interfaces bound automatically, nil if disabled
+	implemented []string
+	// err is a cached construction error
+	err error
+	// prepared is a schema revision where node graph was checked
+	prepared struct {
+		schema   *defaultSchema
+		revision int
+	}
+}
+
+// as creates interface node of type rt that shares instance with node.
+func (n *node) as(rt reflect.Type) *node {
+	return &node{
+		rv:           n.rv,
+		rt:           rt,
+		tags:         n.tags,
+		frame:        n.frame,
+		origin:       n,
+		dependencies: n.dependencies,
+		namespace:    n.namespace,
+		cacheError:   n.cacheError,
+		lifetime:     n.lifetime,
+		cache:        n.cache,
+		sensitive:    n.sensitive,
+		order:        n.order,
+		compiler:     n.compiler,
+		decorators:   n.decorators,
+		setters:      n.setters,
+		tagged:       n.tagged,
+		byDefault:    n.byDefault,
+		primary:      n.primary,
+	}
 }
 
 // String is a string representation of node.
@@ -57,32 +127,111 @@ func (n *node) String() string {
 	return fmt.Sprintf("%s%s", n.rt, n.tags)
 }
 
-// Value returns value of node.
+// Value returns value of node. The value resolved through middlewares of resolving schema.
 func (n *node) Value(s schema) (reflect.Value, error) {
+	return s.scope().resolve(n, func() (reflect.Value, error) {
+		return n.value(s)
+	})
+}
+
+// value returns cached value of node or builds it.
+func (n *node) value(s schema) (reflect.Value, error) {
 	if n.rv.IsValid() {
 		return *n.rv, nil
 	}
-	nodes, _ := n.deps(s) // todo: error skipped, prepare already check dependency graph
-	var dependencies []reflect.Value
+	// interface nodes share construction error and scoped value with origin
+	owner := n.instance()
+	switch n.lifetime {
+	case Scoped:
+		if rv, ok := s.scope().scopedValue(owner); ok {
+			return rv, nil
+		}
+	case Cached:
+		if v, ok := n.cache.Load(); ok {
+			return valueOf(n.rt, v)
+		}
+	}
+	if owner.err != nil {
+		return reflect.Value{}, owner.err
+	}
+	start := time.Now()
+	rv, err := n.build(s)
+	s.scope().record(HistoryBuild, n, start, err)
+	if err != nil {
+		if n.cacheError {
+			owner.err = err
+		}
+		return reflect.Value{}, err
+	}
+	switch n.lifetime {
+	case Singleton:
+		*n.rv = rv
+	case Scoped:
+		s.scope().storeScopedValue(owner, rv)
+	case Cached:
+		n.cache.Store(rv.Interface())
+	}
+	return rv, nil
+}
+
+// build builds value of node.
+func (n *node) build(s schema) (reflect.Value, error) {
+	// singleton shared by schema where node was registered and its descendants, so it built
+	// with dependencies of that schema even if resolved through child
+	if n.lifetime == Singleton && n.owner != nil && s.scope() != n.owner {
+		if err := n.owner.prepare(n); err != nil {
+			return reflect.Value{}, err
+		}
+		s = n.owner
+	}
+	lookup := n.lookup(s)
+	nodes, _ := n.deps(lookup) // todo: error skipped, prepare already check dependency graph
+	values := acquireValues()
+	defer releaseValues(values)
+	// members of group authorized with consumer of group
+	_, group := n.compiler.(memberCompiler)
 	for _, node := range nodes {
+		if !group {
+			if err := s.authorize(n, node); err != nil {
+				return reflect.Value{}, err
+			}
+		}
 		v, err := node.Value(s)
 		if err != nil {
 			return reflect.Value{}, fmt.Errorf("%s: %w", node, err)
 		}
-		dependencies = append(dependencies, v)
+		*values = append(*values, v)
 	}
-	rv, err := n.compile(dependencies, s)
+	if err := s.context().Err(); err != nil {
+		return reflect.Value{}, fmt.Errorf("construction of %s interrupted: %w", n, err)
+	}
+	// cleanups of singletons belong to schema where node was registered, cleanups of
+	// other lifetimes belong to resolving scope
+	owner := s.scope()
+	registered := len(owner.cleanups)
+	rv, err := n.compile(*values, owner)
 	if err != nil {
 		tracer.Trace("%s: %s", n.String(), err)
 		return reflect.Value{}, err
 	}
+	for i := registered; i < len(owner.cleanups); i++ {
+		owner.cleanups[i] = owner.recordCleanup(n, owner.cleanups[i])
+	}
+	// interface nodes share instance with origin
+	if n.lifetime == Singleton {
+		n.instance().cleanups = append(n.instance().cleanups, owner.cleanups[registered:]...)
+	}
 	// if result value not addr, create pointer for it
 	if !rv.CanAddr() {
 		addr := reflect.New(rv.Type())
 		addr.Elem().Set(rv)
 		rv = addr.Elem()
 	}
-	if err := populate(s, rv); err != nil {
+	if err := populate(s, lookup, n, rv); err != nil {
+		tracer.Trace("%s: %s", n.String(), err)
+		return reflect.Value{}, err
+	}
+	if err := callSetters(s, lookup, n, rv); err != nil {
 		tracer.Trace("%s: %s", n.String(), err)
 		return reflect.Value{}, err
 	}
@@ -93,33 +242,65 @@ func (n *node) Value(s schema) (reflect.Value, error) {
 			return reflect.Value{}, err
 		}
 	}
-	*n.rv = rv
 	tracer.Trace("Resolved %s", n.String())
-	return *n.rv, nil
+	return rv, nil
 }
 
-func (n *node) fields() map[int]field {
-	return parsePopulateFields(n.rt)
+// instance returns node that owns instance: origin for interface nodes or node itself.
+func (n *node) instance() *node {
+	if n.origin != nil {
+		return n.origin
+	}
+	return n
 }
 
-// populate populates node fields.
-func populate(s schema, rv reflect.Value) error {
-	if !canInject(rv.Type()) {
+// lookup returns schema that used to find node dependencies.
+func (n *node) lookup(s schema) schema {
+	if n.namespace == "" {
+		return s
+	}
+	return namespacedSchema{
+		schema: s,
+		prefix: n.namespace,
+	}
+}
+
+// fields returns injectable fields of value of type rt built by node.
+func (n *node) fields(rt reflect.Type, tagName string) map[int]field {
+	if n.tagged {
+		return fillFields(rt, tagName)
+	}
+	return parsePopulateFields(rt, tagName)
+}
+
+// populate populates fields of consumer value. The lookup is a schema that used to find
+// field nodes.
+func populate(s schema, lookup schema, consumer *node, rv reflect.Value) error {
+	fields := consumer.fields(rv.Type(), s.scope().tagName)
+	if len(fields) == 0 {
 		return nil
 	}
 	// indirect pointer
 	if rv.Kind() == reflect.Ptr {
 		rv = reflect.Indirect(rv)
 	}
-	for index, field := range parsePopulateFields(rv.Type()) {
-		node, err := s.find(field.rt, field.tags)
+	for index, field := range fields {
+		node, err := lookup.find(field.rt, field.tags)
 		if err != nil && field.optional {
 			tracer.Trace("-- Skip optional field: %s", field)
+			s.warn(Warning{
+				Kind:    WarningUnusedOptional,
+				Type:    rv.Type(),
+				Message: fmt.Sprintf("optional field %s%s of %s skipped", field.rt, field.tags, rv.Type()),
+			})
 			continue
 		}
 		if err != nil {
 			return err
 		}
+		if err := s.authorize(consumer, node); err != nil {
+			return err
+		}
 		v, err := node.Value(s)
 		if err != nil {
 			return err
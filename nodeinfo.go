@@ -0,0 +1,206 @@
+package di
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var injectType = reflect.TypeOf(Inject{})
+
+// TypeRef is a reference to type with tags that definition requires.
+type TypeRef struct {
+	// Type is a required type.
+	Type reflect.Type
+	// Tags is a tags that required type definition must contain.
+	Tags Tags
+	// Optional is true when dependency is an optional field.
+	Optional bool
+}
+
+// String is a string representation of type reference.
+func (r TypeRef) String() string {
+	return r.Type.String() + r.Tags.String()
+}
+
+// NodeInfo is a description of container definition.
+type NodeInfo struct {
+	// Type is a provided type.
+	Type reflect.Type
+	// Tags is a tags of provided type.
+	Tags Tags
+	// Interfaces is a list of interfaces registered with di.As().
+	Interfaces []reflect.Type
+	// Lifetime is a lifetime of provided type instances.
+	Lifetime Lifetime
+	// Sensitive is true if definition marked with di.Sensitive().
+	Sensitive bool
+	// Dependencies is a list of declared dependencies: constructor arguments and injectable
+	// fields. Fields of parameter structs declared instead of struct itself. They captured on
+	// provide and not resolved, so they can reference types that not exist in the container.
+	// Dependencies of di.Factory captured when factory resolves them.
+	Dependencies []TypeRef
+	// Source is a file:line where type was provided. It is empty for types provided by
+	// container itself.
+	Source string
+}
+
+// Definitions returns descriptions of the container definitions sorted by type and tags.
+// Definitions of ancestors included. Interfaces and implicitly injected types are not included.
+//
+//	for _, def := range container.Definitions() {
+//		fmt.Println(def.Type, def.Dependencies)
+//	}
+func (c *Container) Definitions() []NodeInfo {
+	var infos []NodeInfo
+	for _, n := range c.schema.all() {
+		if n.origin != nil || n.implicit {
+			continue
+		}
+		infos = append(infos, n.info())
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].Type.String()+infos[i].Tags.String() < infos[j].Type.String()+infos[j].Tags.String()
+	})
+	return infos
+}
+
+// Providers returns descriptions of definitions registered by Provide() and ProvideValue()
+// with their source locations. Unlike Definitions() types provided by container itself are
+// not included.
+//
+//	for _, p := range container.Providers() {
+//		fmt.Printf("%s%s provided at %s\n", p.Type, p.Tags, p.Source)
+//	}
+func (c *Container) Providers() []NodeInfo {
+	var infos []NodeInfo
+	for _, info := range c.Definitions() {
+		if info.Source != "" {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// Fingerprint returns stable hash of the container definitions graph: types, tags,
+// interfaces, lifetimes and declared dependencies. Source locations do not affect the
+// fingerprint, so it changes only when wiring changes.
+//
+//	if container.Fingerprint() != cached {
+//		// regenerate artifacts
+//	}
+func (c *Container) Fingerprint() string {
+	h := sha256.New()
+	for _, info := range c.Definitions() {
+		interfaces := make([]string, 0, len(info.Interfaces))
+		for _, i := range info.Interfaces {
+			interfaces = append(interfaces, i.String())
+		}
+		sort.Strings(interfaces)
+		deps := make([]string, 0, len(info.Dependencies))
+		for _, dep := range info.Dependencies {
+			deps = append(deps, fmt.Sprintf("%s:%t", dep, dep.Optional))
+		}
+		_, _ = fmt.Fprintf(h, "%s%s(%s)%s[%s]\n", info.Type, info.Tags, strings.Join(interfaces, ","), info.Lifetime, strings.Join(deps, ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// info returns description of node.
+func (n *node) info() NodeInfo {
+	return NodeInfo{
+		Type:         n.rt,
+		Tags:         n.tags,
+		Interfaces:   n.interfaces,
+		Lifetime:     n.lifetime,
+		Sensitive:    n.sensitive,
+		Dependencies: n.dependencies,
+		Source:       n.frame.source(),
+	}
+}
+
+// declaredDependencies returns dependencies that node declares: constructor arguments, setter
+// arguments and injectable fields in order of declaration. Arguments of parameter struct type
+// declared as its fields. The tagName is a struct tag key of field tags.
+func declaredDependencies(n *node, tagName string) (deps []TypeRef) {
+	switch cmp := n.compiler.(type) {
+	case *constructorCompiler:
+		deps = argumentDependencies(cmp.fn.Type, tagName)
+	case *selectCompiler:
+		deps = argumentDependencies(cmp.selector.Type, tagName)
+	case *shadowCompiler:
+		// new implementation built together with old one, so node depends on both of them
+		for _, impl := range []*node{cmp.old, cmp.new} {
+			deps = appendDependencies(deps, declaredDependencies(impl, tagName)...)
+		}
+	}
+	for _, setter := range n.setters {
+		for _, arg := range setter.args {
+			deps = append(deps, parameterDependencies(arg, tagName)...)
+		}
+	}
+	return append(deps, fieldDependencies(n.fields(n.rt, tagName))...)
+}
+
+// argumentDependencies returns dependencies declared by arguments of function type fn.
+func argumentDependencies(fn reflect.Type, tagName string) (deps []TypeRef) {
+	for i := 0; i < fn.NumIn(); i++ {
+		deps = append(deps, parameterDependencies(fn.In(i), tagName)...)
+	}
+	return deps
+}
+
+// parameterDependencies returns dependency on type t or on fields of t if it is a parameter
+// struct: struct with embedded di.Inject passed by value.
+func parameterDependencies(t reflect.Type, tagName string) []TypeRef {
+	if !isParameterStruct(t) {
+		return []TypeRef{{Type: t, Tags: Tags{}}}
+	}
+	return fieldDependencies(parsePopulateFields(t, tagName))
+}
+
+// isParameterStruct checks that t is a parameter struct.
+func isParameterStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && canInject(t)
+}
+
+// fieldDependencies returns dependencies declared by injectable fields in order of declaration.
+func fieldDependencies(fields map[int]field) (deps []TypeRef) {
+	indexes := make([]int, 0, len(fields))
+	for index := range fields {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		// embedded di.Inject is a marker, not a dependency
+		if fields[index].rt == injectType {
+			continue
+		}
+		deps = append(deps, TypeRef{
+			Type:     fields[index].rt,
+			Tags:     fields[index].tags,
+			Optional: fields[index].optional,
+		})
+	}
+	return deps
+}
+
+// appendDependencies appends refs to deps skipping already declared ones.
+func appendDependencies(deps []TypeRef, refs ...TypeRef) []TypeRef {
+	for _, ref := range refs {
+		declared := false
+		for _, dep := range deps {
+			if dep.Type == ref.Type && dep.Tags.String() == ref.Tags.String() {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			deps = append(deps, ref)
+		}
+	}
+	return deps
+}
@@ -37,6 +37,36 @@ func ProvideValue(value Value, options ...ProvideOption) Option {
 	})
 }
 
+// Registry is a module object that exposes its constructors. See di.ProvideFrom().
+type Registry interface {
+	Constructors() []interface{}
+}
+
+// ProvideFrom returns container option that provides all constructors of registry. Provide
+// options applied to each constructor.
+//
+//	type Module struct{}
+//
+//	func (Module) Constructors() []interface{} {
+//		return []interface{}{NewServer, NewServeMux}
+//	}
+//
+//	container, err := di.New(
+//		di.ProvideFrom(Module{}),
+//	)
+func ProvideFrom(registry Registry, options ...ProvideOption) Option {
+	frame := stacktrace(0)
+	return option(func(c *diopts) {
+		for _, constructor := range registry.Constructors() {
+			c.provides = append(c.provides, provideOptions{
+				frame,
+				constructor,
+				options,
+			})
+		}
+	})
+}
+
 // Constructor is a function with follow signature:
 //
 // 	func NewHTTPServer(addr string, handler http.Handler) (server *http.Server, cleanup func(), err error) {
@@ -54,6 +84,9 @@ func ProvideValue(value Value, options ...ProvideOption) Option {
 // count of dependencies, but note that container should know how build each of them.
 // Second result of this function is a optional cleanup callback. It describes that container will do on shutdown.
 // Third result is a optional error. Sometimes our types cannot be constructed.
+// Constructor can also be a di.Factory when provided type decided at runtime.
+// Dependency of type func() (T, error) is a provider function that resolves T on each call. With di.Transient
+// lifetime of T it gives new instance on each call, even to singleton consumer.
 type Constructor interface{}
 
 // Value is a variable of provided or resolved type.
@@ -144,6 +177,53 @@ func Decorate(decorators ...Decorator) ProvideOption {
 	})
 }
 
+// RetryOnResolve returns provide option that makes container call constructor again on the next
+// resolve if previous construction failed. This is a default behaviour.
+func RetryOnResolve() ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.CacheError = false
+	})
+}
+
+// CacheError returns provide option that makes container remember construction error. All
+// subsequent resolves of the type return the same error without calling constructor.
+func CacheError() ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.CacheError = true
+	})
+}
+
+// WithOrder returns provide option that specifies position of provided type in groups. Group
+// members sorted by order in ascending order, members with equal order keep provide order. The
+// default order is 0.
+//
+//	container, err := di.New(
+//		di.Provide(NewRecoveryMiddleware, di.As(new(Middleware)), di.WithOrder(-100)),
+//		di.Provide(NewAuthMiddleware, di.As(new(Middleware)), di.WithOrder(10)),
+//	)
+func WithOrder(order int) ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.Order = order
+	})
+}
+
+// Sensitive returns provide option that marks definition as sensitive. Tags of sensitive
+// definitions are redacted in exports like Container.Blueprint(), so they are safe to attach
+// to bug reports.
+func Sensitive() ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.Sensitive = true
+	})
+}
+
+// SharedMutable returns provide option that marks definition as intentionally shared mutable
+// state. Such definitions are not reported as WarningSharedMutable in strict mode.
+func SharedMutable() ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.SharedMutable = true
+	})
+}
+
 // Resolve returns container options that resolves type into target. All resolves will be done on compile stage
 // after call invokes.
 func Resolve(target Pointer, options ...ResolveOption) Option {
@@ -171,6 +251,28 @@ func Invoke(fn Invocation, options ...InvokeOption) Option {
 	})
 }
 
+// Instantiate returns container option that applies module options with names qualified by
+// qualifier, like container.ApplyNamespaced(). Dependencies inside module resolved to the
+// qualified versions, so module can be instantiated several times in one container.
+//
+//	container, err := di.New(
+//		di.Instantiate(kafkaModule, "orders"),
+//		di.Instantiate(kafkaModule, "events"),
+//	)
+//	if err != nil {
+//		// handle error
+//	}
+//	var client *kafka.Client
+//	err = container.Resolve(&client, di.Tags{"name": "events"})
+func Instantiate(module Option, qualifier string) Option {
+	return option(func(c *diopts) {
+		var instance diopts
+		module.apply(&instance)
+		instance.namespace(qualifier)
+		c.merge(instance)
+	})
+}
+
 // Options group together container options.
 //
 //   account := di.Options(
@@ -197,11 +299,35 @@ func Options(options ...Option) Option {
 }
 
 // ProvideParams is a Provide() method options. Name is a unique identifier of type instance. Provider is a constructor
-// function. Interfaces is a interface that implements a provider result type.
+// function. Interfaces is a interface that implements a provider result type. CacheError is a construction error
+// caching policy.
 type ProvideParams struct {
-	Tags       Tags
-	Interfaces []Interface
-	Decorators []Decorator
+	Tags          Tags
+	Interfaces    []Interface
+	Decorators    []Decorator
+	CacheError    bool
+	Lifetime      Lifetime
+	Cache         Cache
+	Sensitive     bool
+	Override      bool
+	Eager         bool
+	ExposeFields  bool
+	Order         int
+	Copy          CopyFunc
+	SharedMutable bool
+	Setters       []string
+	AllSetters    bool
+	Default       bool
+	Primary       bool
+	Implemented   []string
+	// qualifier of dependencies
+	namespace string
+	// expression that must be true to provide
+	condition string
+	// predicates that must be true to provide
+	predicates []func(c *Container) bool
+	// profiles which any must be active to provide
+	profiles []string
 }
 
 func (p ProvideParams) applyProvide(params *ProvideParams) {
@@ -217,6 +343,8 @@ type InvokeOption interface {
 type InvokeParams struct {
 	// The function
 	Fn interface{}
+	// qualifier of dependencies
+	namespace string
 }
 
 func (p InvokeParams) apply(params *InvokeParams) {
@@ -240,9 +368,94 @@ func Name(name string) ResolveOption {
 	})
 }
 
+// Selector returns resolve option that selects definitions which tags satisfy selector. The
+// selector parsed once on option creation. See ParseTagSelector() for syntax. It can be used
+// with groups and iteration.
+//
+//	var caches []Cache
+//	err := container.Resolve(&caches, di.Selector("tier=cache,!deprecated"))
+func Selector(selector string) ResolveOption {
+	parsed, err := ParseTagSelector(selector)
+	return resolveOption(func(params *ResolveParams) {
+		params.Selector = parsed
+		params.err = err
+	})
+}
+
+// Exact returns resolve option that requires exactly one definition matched by tags and
+// selector. Group types resolves with error if more than one definition matched. Primary and
+// default definitions are not preferred.
+//
+//	var servers []*http.Server
+//	err := container.Resolve(&servers, di.Tags{"name": "public"}, di.Exact())
+func Exact() ResolveOption {
+	return resolveOption(func(params *ResolveParams) {
+		params.Exact = true
+	})
+}
+
+// FilterTags returns resolve option that filters group members by tags. Unlike di.Tags it
+// never matches a definition of group type itself and filtered group can be empty. It can be
+// used with groups and iteration only.
+//
+//	var handlers []http.Handler
+//	err := container.Resolve(&handlers, di.FilterTags(di.Tags{"role": "admin"}))
+func FilterTags(tags Tags) ResolveOption {
+	return resolveOption(func(params *ResolveParams) {
+		if params.Filter == nil {
+			params.Filter = Tags{}
+		}
+		for k, v := range tags {
+			params.Filter[k] = v
+		}
+	})
+}
+
+// Duplicates returns container option that specifies which definitions considered as
+// duplicates. The duplicates reported as WarningShadowed in Container.Warnings(). By default,
+// duplicates are definitions with the same type and tags.
+//
+//	container, err := di.New(
+//		di.Duplicates(di.DuplicateTypeName),
+//		di.Provide(NewPublicServer, di.Tags{"name": "public", "port": "80"}),
+//		di.Provide(NewPublicTLSServer, di.Tags{"name": "public", "port": "443"}),
+//	)
+func Duplicates(rule DuplicateRule) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.duplicates = rule
+		})
+	})
+}
+
+// WithTagName returns container option that specifies struct tag key of injectable fields.
+// By default, the key is "di". Deprecated tagging style is not supported with custom key.
+//
+//	type Application struct {
+//		di.Inject
+//		Server *http.Server `inject:"name=public"`
+//	}
+//
+//	container, err := di.New(
+//		di.WithTagName("inject"),
+//		di.Provide(NewPublicServer, di.Tags{"name": "public"}),
+//	)
+func WithTagName(name string) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.schema.tagName = name
+		})
+	})
+}
+
 // ResolveParams is a resolve parameters.
 type ResolveParams struct {
-	Tags Tags
+	Tags     Tags
+	Selector TagSelector
+	Exact    bool
+	Filter   Tags
+	// error of options
+	err error
 }
 
 func (p ResolveParams) applyResolve(params *ResolveParams) {
@@ -259,6 +472,12 @@ func (o provideOption) applyProvide(params *ProvideParams) {
 	o(params)
 }
 
+type invokeOption func(params *InvokeParams)
+
+func (o invokeOption) apply(params *InvokeParams) {
+	o(params)
+}
+
 type resolveOption func(params *ResolveParams)
 
 func (o resolveOption) applyResolve(params *ResolveParams) {
@@ -286,6 +505,14 @@ type invokeOptions struct {
 	options []InvokeOption
 }
 
+// struct that contains source of included options.
+type includeOptions struct {
+	frame  callerFrame
+	source Source
+	// namespaces applied to included options from innermost
+	namespaces []string
+}
+
 // struct that container resolve target with options.
 type resolveOptions struct {
 	frame   callerFrame
@@ -0,0 +1,70 @@
+package di
+
+import (
+	"reflect"
+)
+
+// Out indicates that exported fields of constructor result struct provided as separate types.
+// Fields provided with tags of di tag, fields with di:"-" skipped. Field values built from
+// result instance and share its lifetime.
+//
+//	type Databases struct {
+//		di.Out
+//
+//		Primary *sql.DB `di:"name=primary"`
+//		Replica *sql.DB `di:"name=replica"`
+//	}
+//
+//	func NewDatabases(config *Config) (Databases, error) {
+//		// ...
+//	}
+//
+//	container, err := di.New(
+//		di.Provide(NewDatabases),
+//	)
+//	var replica *sql.DB
+//	err = container.Resolve(&replica, di.Name("replica"))
+type Out struct {
+	out
+}
+
+// out is a marker of result struct.
+type out struct{}
+
+// outType is a reflect.Type of di.Out.
+var outType = reflect.TypeOf(Out{})
+
+// isOut checks that t is a struct or pointer to struct that embeds di.Out.
+func isOut(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Anonymous && f.Type == outType {
+			return true
+		}
+	}
+	return false
+}
+
+// provideOutFields provides exported fields of result struct node with tags of field.
+func (c *Container) provideOutFields(n *node) {
+	rt := n.rt
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" || f.Type == outType {
+			continue
+		}
+		parsed, ok := inspectStructField(rt, f, c.schema.tagName)
+		if !ok {
+			continue
+		}
+		c.provideField(n, i, parsed.tags)
+	}
+}
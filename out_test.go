@@ -0,0 +1,71 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestOut(t *testing.T) {
+	type Servers struct {
+		di.Out
+		Public  *http.Server `di:"name=public"`
+		Private *http.Server `di:"name=private"`
+		Mux     *http.ServeMux
+		Skipped *http.Client `di:"-"`
+		private *http.Client
+	}
+
+	t.Run("fields of result struct provided", func(t *testing.T) {
+		var built int
+		public := &http.Server{Addr: ":80"}
+		private := &http.Server{Addr: ":8080"}
+		c, err := di.New(
+			di.Provide(func() Servers {
+				built++
+				return Servers{Public: public, Private: private, Mux: http.NewServeMux(), Skipped: &http.Client{}}
+			}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server, di.Name("public")))
+		require.Same(t, public, server)
+		require.NoError(t, c.Resolve(&server, di.Name("private")))
+		require.Same(t, private, server)
+		var mux *http.ServeMux
+		require.NoError(t, c.Resolve(&mux))
+		require.NotNil(t, mux)
+		var servers []*http.Server
+		require.NoError(t, c.Resolve(&servers))
+		require.Len(t, servers, 2)
+		require.Equal(t, 1, built)
+		has, err := c.Has(new(*http.Client))
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("pointer to result struct", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() (*Servers, error) { return &Servers{Mux: http.NewServeMux()}, nil }),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		require.NoError(t, c.Resolve(&mux))
+		require.NotNil(t, mux)
+	})
+
+	t.Run("constructor error", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() (Servers, error) { return Servers{}, errors.New("servers error") }),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		err = c.Resolve(&mux)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "servers error")
+	})
+}
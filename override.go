@@ -0,0 +1,129 @@
+package di
+
+import (
+	"reflect"
+)
+
+// Override returns provide option that replaces definitions of the container with the same
+// type and tags (see di.Duplicates() to change the rule). Cached values of replaced definitions
+// and values that depend on them are dropped and built again on next resolve. It useful
+// to layer test configuration on top of production wiring.
+//
+//	err := container.Provide(NewFakeMailer, di.As(new(Mailer)), di.Override())
+func Override() ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.Override = true
+	})
+}
+
+// Default returns provide option that marks definition as default implementation. The default
+// definition used only if no other definitions match, so modules can offer implementations that
+// applications replace just by providing their own ones.
+//
+//	func (m *Module) Options() di.Option {
+//		return di.Options(
+//			di.Provide(NewNopLogger, di.As(new(Logger)), di.Default()),
+//		)
+//	}
+//
+//	container, err := di.New(
+//		module.Options(),
+//		di.Provide(NewZapLogger, di.As(new(Logger))), // used instead of default
+//	)
+func Default() ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.Default = true
+	})
+}
+
+// Primary returns provide option that marks definition as primary one. If many definitions
+// match single value resolution, the primary definition resolved instead of ErrAmbiguousType.
+// Group resolution includes all definitions.
+//
+//	container, err := di.New(
+//		di.Provide(NewPostgresStorage, di.As(new(Storage)), di.Primary()),
+//		di.Provide(NewRedisStorage, di.As(new(Storage))),
+//	)
+//	var storage Storage // postgres storage
+//	var storages []Storage // both storages
+func Primary() ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.Primary = true
+	})
+}
+
+// override removes definitions replaced by n and invalidates values that depend on them.
+func (c *Container) override(n *node) {
+	s := c.schema
+	replaced := map[*node]bool{}
+	var kept []*node
+	for _, cur := range s.nodes[n.rt] {
+		if !cur.implicit && c.duplicates.equal(cur, n) {
+			replaced[cur] = true
+			continue
+		}
+		kept = append(kept, cur)
+	}
+	if len(replaced) == 0 {
+		return
+	}
+	s.nodes[n.rt] = kept
+	implementers := s.implementers[:0]
+	for _, cur := range s.implementers {
+		if !replaced[cur] {
+			implementers = append(implementers, cur)
+		}
+	}
+	s.implementers = implementers
+	changed := map[reflect.Type]bool{n.rt: true}
+	// remove interfaces registered by replaced definitions
+	for t, nodes := range s.nodes {
+		kept := nodes[:0]
+		for _, cur := range nodes {
+			if cur.origin != nil && replaced[cur.origin] {
+				changed[t] = true
+				continue
+			}
+			kept = append(kept, cur)
+		}
+		s.nodes[t] = kept
+	}
+	s.version++
+	s.invalidate(replaced, changed)
+}
+
+// invalidate resets cached values of invalid nodes and own nodes that depend on changed types
+// directly or transitively. Cleanups of dropped values run.
+func (s *defaultSchema) invalidate(invalid map[*node]bool, changed map[reflect.Type]bool) {
+	for found := true; found; {
+		found = false
+		for _, nodes := range s.nodes {
+			for _, n := range nodes {
+				if n.origin != nil || invalid[n] || !n.dependsOn(changed, s.tagName) {
+					continue
+				}
+				invalid[n] = true
+				found = true
+				changed[n.rt] = true
+				for _, i := range n.interfaces {
+					changed[i] = true
+				}
+			}
+		}
+	}
+	s.reset(invalid)
+}
+
+// dependsOn checks that node declares dependency on one of types or on group of them.
+func (n *node) dependsOn(types map[reflect.Type]bool, tagName string) bool {
+	deps := n.dependencies
+	if n.implicit {
+		deps = declaredDependencies(n, tagName)
+	}
+	for _, dep := range deps {
+		if types[dep.Type] || dep.Type.Kind() == reflect.Slice && types[dep.Type.Elem()] {
+			return true
+		}
+	}
+	return false
+}
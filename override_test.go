@@ -0,0 +1,189 @@
+package di_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestOverride(t *testing.T) {
+	t.Run("override replaces definition", func(t *testing.T) {
+		fake := &http.ServeMux{}
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler))),
+			di.Provide(func() *http.ServeMux { return fake }, di.As(new(http.Handler)), di.Override()),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		require.NoError(t, c.Resolve(&mux))
+		require.Equal(t, fmt.Sprintf("%p", fake), fmt.Sprintf("%p", mux))
+		var handlers []http.Handler
+		require.NoError(t, c.Resolve(&handlers))
+		require.Len(t, handlers, 1)
+		require.Empty(t, c.Warnings())
+	})
+
+	t.Run("override keeps definitions with other tags", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.Tags{"name": "public"}),
+			di.Provide(http.NewServeMux, di.Tags{"name": "private"}),
+			di.Provide(http.NewServeMux, di.Tags{"name": "public"}, di.Override()),
+		)
+		require.NoError(t, err)
+		var muxs []*http.ServeMux
+		require.NoError(t, c.Resolve(&muxs))
+		require.Len(t, muxs, 2)
+	})
+
+	t.Run("override invalidates cached values of dependents", func(t *testing.T) {
+		type Handler struct {
+			di.Inject
+			Server *http.Server
+		}
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux { return &http.ServeMux{} }),
+			di.Provide(func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} }),
+			di.Provide(func() *Handler { return &Handler{} }),
+			di.Provide(func() *http.Client { return &http.Client{} }),
+		)
+		require.NoError(t, err)
+		var handler *Handler
+		require.NoError(t, c.Resolve(&handler))
+		var client *http.Client
+		require.NoError(t, c.Resolve(&client))
+		fake := &http.ServeMux{}
+		require.NoError(t, c.Provide(func() *http.ServeMux { return fake }, di.Override()))
+		var overridden *Handler
+		require.NoError(t, c.Resolve(&overridden))
+		require.Equal(t, fmt.Sprintf("%p", fake), fmt.Sprintf("%p", overridden.Server.Handler))
+		require.False(t, handler == overridden)
+		var same *http.Client
+		require.NoError(t, c.Resolve(&same))
+		require.Equal(t, fmt.Sprintf("%p", client), fmt.Sprintf("%p", same))
+	})
+
+	t.Run("override without existing definition provides type", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.Override()),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		require.NoError(t, c.Resolve(&mux))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("default used if no other definitions", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler)), di.Default()),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		require.NoError(t, c.Resolve(&handler))
+		require.IsType(t, &http.ServeMux{}, handler)
+	})
+
+	t.Run("other definition preferred to default", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler)), di.Default()),
+			di.Provide(http.NotFoundHandler),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		require.NoError(t, c.Resolve(&handler))
+		_, ok := handler.(*http.ServeMux)
+		require.False(t, ok)
+		var handlers []http.Handler
+		require.NoError(t, c.Resolve(&handlers))
+		require.Len(t, handlers, 1)
+		require.Empty(t, c.Warnings())
+	})
+
+	t.Run("default of parent replaced by child definition", func(t *testing.T) {
+		parent, err := di.New(
+			di.ProvideValue(&http.Server{Addr: ":80"}, di.Default()),
+		)
+		require.NoError(t, err)
+		child, err := parent.NewChild(
+			di.ProvideValue(&http.Server{Addr: ":8080"}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, child.Resolve(&server))
+		require.Equal(t, ":8080", server.Addr)
+		require.NoError(t, parent.Resolve(&server))
+		require.Equal(t, ":80", server.Addr)
+	})
+
+	t.Run("many defaults are ambiguous", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{Addr: ":80"}, di.Default()),
+			di.ProvideValue(&http.Server{Addr: ":8080"}, di.Default()),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, di.ErrAmbiguousType))
+	})
+}
+
+func TestPrimary(t *testing.T) {
+	t.Run("primary resolved from many definitions", func(t *testing.T) {
+		mux := http.NewServeMux()
+		c, err := di.New(
+			di.Provide(http.NotFoundHandler),
+			di.ProvideValue(mux, di.As(new(http.Handler)), di.Primary()),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		require.NoError(t, c.Resolve(&handler))
+		require.Same(t, mux, handler)
+		var handlers []http.Handler
+		require.NoError(t, c.Resolve(&handlers))
+		require.Len(t, handlers, 2)
+	})
+
+	t.Run("primary of parent and definition of child", func(t *testing.T) {
+		parent, err := di.New(
+			di.ProvideValue(&http.Server{Addr: ":80"}, di.Primary()),
+		)
+		require.NoError(t, err)
+		child, err := parent.NewChild(
+			di.ProvideValue(&http.Server{Addr: ":8080"}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, child.Resolve(&server))
+		require.Equal(t, ":8080", server.Addr)
+	})
+
+	t.Run("many primaries are ambiguous", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{Addr: ":80"}, di.Primary()),
+			di.ProvideValue(&http.Server{Addr: ":8080"}, di.Primary()),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, di.ErrAmbiguousType))
+	})
+
+	t.Run("primary not resolved by exact query", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{Addr: ":80"}, di.Primary()),
+			di.ProvideValue(&http.Server{Addr: ":8080"}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server, di.Exact())
+		require.Error(t, err)
+		require.True(t, errors.Is(err, di.ErrAmbiguousType))
+	})
+}
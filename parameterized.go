@@ -0,0 +1,98 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// parameterized is a definition of factory function with runtime parameters.
+type parameterized struct {
+	target      Pointer
+	constructor Constructor
+}
+
+// ProvideFactory returns container option that provides factory function with runtime
+// parameters. The target is a pointer to function type of factory. Leading parameters of
+// constructor are parameters of factory supplied by caller, rest of them are dependencies
+// resolved from container once, when factory built. Results of constructor must be the same
+// as results of factory: T or (T, error).
+//
+//	type BucketFactory func(name string) (*Bucket, error)
+//
+//	container, err := di.New(
+//		di.Provide(NewS3Client),
+//		di.ProvideFactory(new(BucketFactory), func(name string, client *s3.Client) (*Bucket, error) {
+//			return &Bucket{name: name, client: client}, nil
+//		}),
+//	)
+//
+//	var newBucket BucketFactory
+//	if err := container.Resolve(&newBucket); err != nil {
+//		// handle error
+//	}
+//	bucket, err := newBucket("assets")
+func ProvideFactory(target Pointer, constructor Constructor, options ...ProvideOption) Option {
+	frame := stacktrace(0)
+	return option(func(c *diopts) {
+		c.provides = append(c.provides, provideOptions{
+			frame,
+			&parameterized{
+				target:      target,
+				constructor: constructor,
+			},
+			options,
+		})
+	})
+}
+
+// newParameterizedNode creates node of factory function. The node is a node of generated
+// constructor that resolves dependencies and returns factory.
+func newParameterizedNode(p *parameterized) (*node, error) {
+	rt := reflect.TypeOf(p.target)
+	if rt == nil || rt.Kind() != reflect.Ptr || rt.Elem().Kind() != reflect.Func {
+		return nil, fmt.Errorf("%w, factory target must be a pointer to function, got %s", ErrInvalidConstructor, rt)
+	}
+	ft := rt.Elem()
+	fn, valid := inspectFunction(p.constructor)
+	if !valid || !matchFactory(ft, fn.Type) {
+		return nil, fmt.Errorf("%w, constructor %s does not match factory %s", ErrInvalidConstructor, reflect.TypeOf(p.constructor), ft)
+	}
+	var deps []reflect.Type
+	for i := ft.NumIn(); i < fn.NumIn(); i++ {
+		deps = append(deps, fn.In(i))
+	}
+	ctor := reflect.MakeFunc(reflect.FuncOf(deps, []reflect.Type{ft}, false), func(dependencies []reflect.Value) []reflect.Value {
+		factory := reflect.MakeFunc(ft, func(params []reflect.Value) []reflect.Value {
+			args := make([]reflect.Value, 0, len(params)+len(dependencies))
+			args = append(args, params...)
+			args = append(args, dependencies...)
+			return fn.Call(args)
+		})
+		return []reflect.Value{factory}
+	})
+	return newConstructorNode(ctor.Interface())
+}
+
+// matchFactory checks that constructor has leading parameters and results of factory.
+func matchFactory(factory reflect.Type, constructor reflect.Type) bool {
+	if factory.IsVariadic() || constructor.IsVariadic() {
+		return false
+	}
+	if factory.NumOut() == 0 || factory.NumOut() > 2 || constructor.NumIn() < factory.NumIn() || constructor.NumOut() != factory.NumOut() {
+		return false
+	}
+	if factory.NumOut() == 2 && factory.Out(1) != errorInterface {
+		return false
+	}
+	for i := 0; i < factory.NumIn(); i++ {
+		if factory.In(i) != constructor.In(i) {
+			return false
+		}
+	}
+	for i := 0; i < factory.NumOut(); i++ {
+		if factory.Out(i) != constructor.Out(i) {
+			return false
+		}
+	}
+	return true
+}
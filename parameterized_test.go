@@ -0,0 +1,90 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestProvideFactory(t *testing.T) {
+	type ServerFactory func(addr string) (*http.Server, error)
+
+	t.Run("factory with runtime parameters and dependencies", func(t *testing.T) {
+		mux := http.NewServeMux()
+		var built int
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux {
+				built++
+				return mux
+			}),
+			di.ProvideFactory(new(ServerFactory), func(addr string, mux *http.ServeMux) (*http.Server, error) {
+				if addr == "" {
+					return nil, errors.New("empty address")
+				}
+				return &http.Server{Addr: addr, Handler: mux}, nil
+			}),
+		)
+		require.NoError(t, err)
+		var newServer ServerFactory
+		require.NoError(t, c.Resolve(&newServer))
+		public, err := newServer(":80")
+		require.NoError(t, err)
+		require.Equal(t, ":80", public.Addr)
+		require.Same(t, mux, public.Handler)
+		private, err := newServer(":8080")
+		require.NoError(t, err)
+		require.Equal(t, ":8080", private.Addr)
+		require.Equal(t, 1, built)
+		_, err = newServer("")
+		require.EqualError(t, err, "empty address")
+	})
+
+	t.Run("unnamed function type without error", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideFactory(new(func(addr string) *http.Server), func(addr string) *http.Server {
+				return &http.Server{Addr: addr}
+			}),
+		)
+		require.NoError(t, err)
+		var newServer func(addr string) *http.Server
+		require.NoError(t, c.Resolve(&newServer))
+		require.Equal(t, ":80", newServer(":80").Addr)
+	})
+
+	t.Run("dependencies checked on resolve", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideFactory(new(ServerFactory), func(addr string, mux *http.ServeMux) (*http.Server, error) {
+				return &http.Server{}, nil
+			}),
+		)
+		require.NoError(t, err)
+		var newServer ServerFactory
+		err = c.Resolve(&newServer)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type *http.ServeMux not exists in the container")
+	})
+
+	t.Run("constructor that does not match factory causes error", func(t *testing.T) {
+		_, err := di.New(
+			di.ProvideFactory(new(ServerFactory), func(port int) (*http.Server, error) {
+				return &http.Server{}, nil
+			}),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parameterized_test.go:")
+		require.Contains(t, err.Error(), "does not match factory di_test.ServerFactory")
+		require.True(t, errors.Is(err, di.ErrInvalidConstructor))
+	})
+
+	t.Run("target that is not a pointer to function causes error", func(t *testing.T) {
+		_, err := di.New(
+			di.ProvideFactory(new(*http.Server), func() *http.Server { return nil }),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "factory target must be a pointer to function, got **http.Server")
+	})
+}
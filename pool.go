@@ -0,0 +1,49 @@
+package di
+
+import (
+	"reflect"
+	"sync"
+)
+
+// marksPool pools marks of graph traversal used by prepare.
+var marksPool = sync.Pool{
+	New: func() interface{} {
+		return map[*node]int{}
+	},
+}
+
+// acquireMarks returns empty marks from pool.
+func acquireMarks() map[*node]int {
+	return marksPool.Get().(map[*node]int)
+}
+
+// releaseMarks clears marks and returns them to pool.
+func releaseMarks(marks map[*node]int) {
+	for n := range marks {
+		delete(marks, n)
+	}
+	marksPool.Put(marks)
+}
+
+// valuesPool pools dependency values used by node build.
+var valuesPool = sync.Pool{
+	New: func() interface{} {
+		values := make([]reflect.Value, 0, 8)
+		return &values
+	},
+}
+
+// acquireValues returns empty values slice from pool.
+func acquireValues() *[]reflect.Value {
+	return valuesPool.Get().(*[]reflect.Value)
+}
+
+// releaseValues clears values and returns them to pool. Values must not be retained
+// after release.
+func releaseValues(values *[]reflect.Value) {
+	for i := range *values {
+		(*values)[i] = reflect.Value{}
+	}
+	*values = (*values)[:0]
+	valuesPool.Put(values)
+}
@@ -0,0 +1,39 @@
+package di
+
+// ActivateProfiles returns container option that activates profiles, e.g. "test" or "prod".
+// Definitions provided with di.WithProfile() are provided only if their profile is active.
+// Child containers inherit profiles of container.
+//
+//	container, err := di.New(
+//		di.ActivateProfiles("prod", "metrics"),
+//		di.Provide(NewMemoryStorage, di.WithProfile("dev")),
+//		di.Provide(NewPostgresStorage, di.WithProfile("prod")),
+//		di.Provide(NewPrometheusExporter, di.WithProfile("metrics")),
+//	)
+func ActivateProfiles(profiles ...string) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.schema.profiles = append(c.schema.profiles, profiles...)
+		})
+	})
+}
+
+// WithProfile returns provide option that provides type only if profile is activated by
+// di.ActivateProfiles(). Definition with many profiles provided if any of them is active.
+func WithProfile(profile string) ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.profiles = append(params.profiles, profile)
+	})
+}
+
+// activeProfile checks that any of profiles is active.
+func (s *defaultSchema) activeProfile(profiles []string) bool {
+	for _, profile := range profiles {
+		for _, active := range s.profiles {
+			if profile == active {
+				return true
+			}
+		}
+	}
+	return false
+}
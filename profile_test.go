@@ -0,0 +1,65 @@
+package di_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestProfiles(t *testing.T) {
+	t.Run("definitions of active profiles provided", func(t *testing.T) {
+		c, err := di.New(
+			di.ActivateProfiles("prod", "metrics"),
+			di.ProvideValue(&http.Server{Addr: ":8080"}, di.WithProfile("dev")),
+			di.ProvideValue(&http.Server{Addr: ":80"}, di.WithProfile("prod")),
+			di.Provide(http.NewServeMux, di.WithProfile("metrics")),
+			di.Provide(func() *http.Client { return &http.Client{} }, di.WithProfile("test")),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, ":80", server.Addr)
+		ok, missing := c.HasAll(new(*http.ServeMux), new(*http.Client))
+		require.False(t, ok)
+		require.Len(t, missing, 1)
+		require.Equal(t, "*http.Client", missing[0].Type.String())
+	})
+
+	t.Run("definition with many profiles provided if any is active", func(t *testing.T) {
+		c, err := di.New(
+			di.ActivateProfiles("test"),
+			di.Provide(http.NewServeMux, di.WithProfile("dev"), di.WithProfile("test")),
+		)
+		require.NoError(t, err)
+		has, err := c.Has(new(*http.ServeMux))
+		require.NoError(t, err)
+		require.True(t, has)
+	})
+
+	t.Run("no active profiles", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.WithProfile("dev")),
+		)
+		require.NoError(t, err)
+		has, err := c.Has(new(*http.ServeMux))
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("child container inherits profiles", func(t *testing.T) {
+		c, err := di.New(
+			di.ActivateProfiles("dev"),
+		)
+		require.NoError(t, err)
+		child, err := c.NewChild(
+			di.Provide(http.NewServeMux, di.WithProfile("dev")),
+		)
+		require.NoError(t, err)
+		has, err := child.Has(new(*http.ServeMux))
+		require.NoError(t, err)
+		require.True(t, has)
+	})
+}
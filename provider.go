@@ -0,0 +1,59 @@
+package di
+
+import (
+	"reflect"
+)
+
+// isProvider checks that t is a provider function type func() (T, error).
+func isProvider(t reflect.Type) bool {
+	return t.Kind() == reflect.Func &&
+		!t.IsVariadic() &&
+		t.NumIn() == 0 &&
+		t.NumOut() == 2 &&
+		t.Out(1) == errorInterface
+}
+
+// providerCompiler compiles provider function of target node. Constructor parameter or
+// injectable field of type func() (T, error) receives function that resolves T on each call,
+// so consumer gets new instance of di.Transient type on each call.
+type providerCompiler struct {
+	rt     reflect.Type
+	target *node
+}
+
+func (c providerCompiler) deps(s schema) ([]*node, error) {
+	// target is not a dependency, it built on provider call
+	return nil, nil
+}
+
+func (c providerCompiler) compile(dependencies []reflect.Value, s schema) (reflect.Value, error) {
+	l := &lazy{target: c.target, schema: s}
+	return reflect.MakeFunc(c.rt, func([]reflect.Value) []reflect.Value {
+		value := reflect.New(c.rt.Out(0)).Elem()
+		rv, err := l.get()
+		if err != nil {
+			return []reflect.Value{value, reflect.ValueOf(&err).Elem()}
+		}
+		value.Set(rv)
+		return []reflect.Value{value, reflect.Zero(errorInterface)}
+	}), nil
+}
+
+func (c providerCompiler) deferred() *node {
+	return c.target
+}
+
+// provider creates node of provider function with target node that matches query.
+func (s *defaultSchema) provider(t reflect.Type, q query) (*node, error) {
+	target, err := s.search(t.Out(0), q)
+	if err != nil {
+		return nil, err
+	}
+	node := &node{
+		compiler: providerCompiler{rt: t, target: target},
+		rt:       t,
+		tags:     q.tags,
+		rv:       new(reflect.Value),
+	}
+	return node, nil
+}
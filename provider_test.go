@@ -0,0 +1,102 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestProviderFunction(t *testing.T) {
+	t.Run("constructor parameter resolves transient on each call", func(t *testing.T) {
+		type Pool struct {
+			newClient func() (*http.Client, error)
+		}
+		var built int
+		c, err := di.New(
+			di.Provide(func() *http.Client {
+				built++
+				return &http.Client{}
+			}, di.WithLifetime(di.Transient)),
+			di.Provide(func(newClient func() (*http.Client, error)) *Pool {
+				return &Pool{newClient: newClient}
+			}),
+		)
+		require.NoError(t, err)
+		var pool *Pool
+		require.NoError(t, c.Resolve(&pool))
+		require.Equal(t, 0, built)
+		client1, err := pool.newClient()
+		require.NoError(t, err)
+		client2, err := pool.newClient()
+		require.NoError(t, err)
+		require.True(t, client1 != client2)
+		require.Equal(t, 2, built)
+	})
+
+	t.Run("injectable field with tags", func(t *testing.T) {
+		type Servers struct {
+			di.Inject
+			Public func() (*http.Server, error) `di:"name=public"`
+		}
+		public := &http.Server{Addr: ":80"}
+		c, err := di.New(
+			di.ProvideValue(public, di.Tags{"name": "public"}),
+			di.ProvideValue(&http.Server{Addr: ":8080"}, di.Tags{"name": "private"}),
+		)
+		require.NoError(t, err)
+		var servers Servers
+		require.NoError(t, c.Resolve(&servers))
+		server, err := servers.Public()
+		require.NoError(t, err)
+		require.Same(t, public, server)
+	})
+
+	t.Run("interface type", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux, di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		var provider func() (http.Handler, error)
+		require.NoError(t, c.Resolve(&provider))
+		handler, err := provider()
+		require.NoError(t, err)
+		require.NotNil(t, handler)
+	})
+
+	t.Run("construction error returned by provider", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() (*http.Server, error) { return nil, errors.New("server error") }),
+		)
+		require.NoError(t, err)
+		var provider func() (*http.Server, error)
+		require.NoError(t, c.Resolve(&provider))
+		server, err := provider()
+		require.EqualError(t, err, "*http.Server: server error")
+		require.Nil(t, server)
+	})
+
+	t.Run("provider of not existing type causes error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		var provider func() (*http.Server, error)
+		err = c.Resolve(&provider)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type *http.Server not exists in the container")
+	})
+
+	t.Run("provided function type preferred", func(t *testing.T) {
+		provided := func() (*http.Server, error) { return nil, errors.New("provided") }
+		c, err := di.New(
+			di.ProvideValue(provided),
+		)
+		require.NoError(t, err)
+		var provider func() (*http.Server, error)
+		require.NoError(t, c.Resolve(&provider))
+		_, err = provider()
+		require.EqualError(t, err, "provided")
+	})
+}
@@ -0,0 +1,69 @@
+package di
+
+import (
+	"reflect"
+)
+
+// Reset drops cached instances of the container definitions and runs their cleanups. The
+// definitions are kept, so instances built again on next resolve. Instances of ancestors are
+// not affected. It useful in tests that reuse expensive to build graph.
+//
+//	for _, tc := range cases {
+//		container.Reset()
+//		// test case gets fresh instances
+//	}
+func (c *Container) Reset() {
+	c.Cleanup()
+	c.schema.cleanups = nil
+	nodes := map[*node]bool{}
+	for _, list := range c.schema.nodes {
+		for _, n := range list {
+			nodes[n] = true
+		}
+	}
+	c.schema.reset(nodes)
+	c.schema.values = nil
+}
+
+// ResetType drops cached instance of the type and instances that depend on it, and runs their
+// cleanups. For group type instances of all group members dropped.
+//
+//	var db *sql.DB
+//	if err := container.ResetType(&db); err != nil {
+//		// handle error
+//	}
+func (c *Container) ResetType(target Pointer, options ...ResolveOption) error {
+	n, err := c.find(target, options...)
+	if err != nil {
+		return errWithStack(err)
+	}
+	targets := []*node{n}
+	if group, ok := n.compiler.(*groupCompiler); ok {
+		targets = group.matched
+	}
+	invalid := map[*node]bool{}
+	changed := map[reflect.Type]bool{}
+	for _, target := range targets {
+		target = target.instance()
+		invalid[target] = true
+		changed[target.rt] = true
+		for _, i := range target.interfaces {
+			changed[i] = true
+		}
+	}
+	c.schema.invalidate(invalid, changed)
+	return nil
+}
+
+// reset drops cached values of nodes and runs their cleanups in reverse order.
+func (s *defaultSchema) reset(nodes map[*node]bool) {
+	for n := range nodes {
+		for i := len(n.cleanups) - 1; i >= 0; i-- {
+			n.cleanups[i]()
+		}
+		n.cleanups = nil
+		*n.rv = reflect.Value{}
+		n.err = nil
+		delete(s.values, n)
+	}
+}
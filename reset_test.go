@@ -0,0 +1,92 @@
+package di_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestContainer_Reset(t *testing.T) {
+	t.Run("reset drops instances and runs cleanups", func(t *testing.T) {
+		var cleaned []string
+		c, err := di.New(
+			di.Provide(func() (*http.ServeMux, func()) {
+				return &http.ServeMux{}, func() { cleaned = append(cleaned, "mux") }
+			}),
+			di.Provide(func(mux *http.ServeMux) (*http.Server, func()) {
+				return &http.Server{Handler: mux}, func() { cleaned = append(cleaned, "server") }
+			}),
+		)
+		require.NoError(t, err)
+		var first, second *http.Server
+		require.NoError(t, c.Resolve(&first))
+		c.Reset()
+		require.Equal(t, []string{"server", "mux"}, cleaned)
+		require.NoError(t, c.Resolve(&second))
+		require.False(t, first == second)
+		require.False(t, first.Handler == second.Handler)
+		c.Cleanup()
+		require.Equal(t, []string{"server", "mux", "server", "mux"}, cleaned)
+	})
+}
+
+func TestContainer_ResetType(t *testing.T) {
+	t.Run("reset type drops instance and dependents", func(t *testing.T) {
+		var cleaned []string
+		c, err := di.New(
+			di.Provide(func() (*http.ServeMux, func()) {
+				return &http.ServeMux{}, func() { cleaned = append(cleaned, "mux") }
+			}, di.As(new(http.Handler))),
+			di.Provide(func(handler http.Handler) (*http.Server, func()) {
+				return &http.Server{Handler: handler}, func() { cleaned = append(cleaned, "server") }
+			}),
+			di.Provide(func() (*http.Client, func()) {
+				return &http.Client{}, func() { cleaned = append(cleaned, "client") }
+			}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		var client *http.Client
+		require.NoError(t, c.Resolve(&client))
+		require.NoError(t, c.ResetType(new(*http.ServeMux)))
+		require.ElementsMatch(t, []string{"server", "mux"}, cleaned)
+		var fresh *http.Server
+		require.NoError(t, c.Resolve(&fresh))
+		require.False(t, server == fresh)
+		require.False(t, server.Handler == fresh.Handler)
+		var same *http.Client
+		require.NoError(t, c.Resolve(&same))
+		require.True(t, client == same)
+		// cleanups run once
+		c.Cleanup()
+		require.ElementsMatch(t, []string{"server", "mux", "client", "mux", "server"}, cleaned)
+	})
+
+	t.Run("reset group type", func(t *testing.T) {
+		created := 0
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux {
+				created++
+				return &http.ServeMux{}
+			}, di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		var handlers []http.Handler
+		require.NoError(t, c.Resolve(&handlers))
+		require.NoError(t, c.ResetType(&handlers))
+		require.NoError(t, c.Resolve(&handlers))
+		require.Equal(t, 2, created)
+	})
+
+	t.Run("reset not existing type cause error", func(t *testing.T) {
+		c, err := di.New()
+		require.NoError(t, err)
+		err = c.ResetType(new(*http.Server))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "reset_test.go:")
+	})
+}
@@ -0,0 +1,79 @@
+package di
+
+import (
+	"reflect"
+)
+
+// ResolutionInfo is an information about current build. Constructor that declares parameter of
+// type di.ResolutionInfo receives it and can adjust behaviour without extra config types.
+//
+//	func NewCache(info di.ResolutionInfo) *Cache {
+//		cache := &Cache{}
+//		if !info.HasProfile("test") {
+//			cache.Warmup()
+//		}
+//		return cache
+//	}
+type ResolutionInfo struct {
+	// Scope is a name of container where type resolved. See di.WithScopeName().
+	Scope string
+	// Profiles is an active profiles of container. See di.ActivateProfiles().
+	Profiles []string
+	// Eager is true if type built on provide by di.Eager() or di.EagerInit().
+	Eager bool
+}
+
+// HasProfile checks that profile is active.
+func (i ResolutionInfo) HasProfile(profile string) bool {
+	for _, cur := range i.Profiles {
+		if cur == profile {
+			return true
+		}
+	}
+	return false
+}
+
+// resolutionInfoType is a reflect.Type of ResolutionInfo.
+var resolutionInfoType = reflect.TypeOf(ResolutionInfo{})
+
+// WithScopeName returns container option that names container, e.g. child container created
+// per request. See di.ResolutionInfo.
+//
+//	ctx, end, err := container.Scope(r.Context(), di.WithScopeName("request"))
+func WithScopeName(name string) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.schema.name = name
+		})
+	})
+}
+
+// resolutionInfoCompiler compiles information about build in resolving schema.
+type resolutionInfoCompiler struct{}
+
+func (c resolutionInfoCompiler) deps(s schema) ([]*node, error) {
+	return nil, nil
+}
+
+func (c resolutionInfoCompiler) compile(dependencies []reflect.Value, s schema) (reflect.Value, error) {
+	scope := s.scope()
+	info := ResolutionInfo{
+		Scope:    scope.name,
+		Profiles: append([]string(nil), scope.profiles...),
+		Eager:    scope.eager,
+	}
+	return reflect.ValueOf(info), nil
+}
+
+// resolutionInfo creates node of information about build. It is transient, so each build
+// receives information of its own.
+func (s *defaultSchema) resolutionInfo() *node {
+	return &node{
+		compiler: resolutionInfoCompiler{},
+		rt:       resolutionInfoType,
+		tags:     Tags{},
+		rv:       new(reflect.Value),
+		lifetime: Transient,
+		implicit: true,
+	}
+}
@@ -0,0 +1,75 @@
+package di_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestResolutionInfo(t *testing.T) {
+	t.Run("constructor receives profiles", func(t *testing.T) {
+		var info di.ResolutionInfo
+		c, err := di.New(
+			di.ActivateProfiles("test", "local"),
+			di.Provide(func(i di.ResolutionInfo) *http.Server {
+				info = i
+				return &http.Server{}
+			}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, []string{"test", "local"}, info.Profiles)
+		require.True(t, info.HasProfile("test"))
+		require.False(t, info.HasProfile("prod"))
+		require.False(t, info.Eager)
+		require.Equal(t, "", info.Scope)
+	})
+
+	t.Run("eager build", func(t *testing.T) {
+		var info di.ResolutionInfo
+		_, err := di.New(
+			di.Provide(func(i di.ResolutionInfo) *http.Server {
+				info = i
+				return &http.Server{}
+			}, di.Eager()),
+		)
+		require.NoError(t, err)
+		require.True(t, info.Eager)
+	})
+
+	t.Run("scope name and inherited profiles", func(t *testing.T) {
+		var info di.ResolutionInfo
+		c, err := di.New(
+			di.ActivateProfiles("prod"),
+			di.Provide(func(i di.ResolutionInfo) *http.Server {
+				info = i
+				return &http.Server{}
+			}, di.WithLifetime(di.Scoped)),
+		)
+		require.NoError(t, err)
+		ctx, end, err := c.Scope(context.Background(), di.WithScopeName("request"))
+		require.NoError(t, err)
+		defer end()
+		scope, ok := di.FromContext(ctx)
+		require.True(t, ok)
+		var server *http.Server
+		require.NoError(t, scope.Resolve(&server))
+		require.Equal(t, "request", info.Scope)
+		require.Equal(t, []string{"prod"}, info.Profiles)
+	})
+
+	t.Run("resolve directly", func(t *testing.T) {
+		c, err := di.New(
+			di.WithScopeName("root"),
+		)
+		require.NoError(t, err)
+		var info di.ResolutionInfo
+		require.NoError(t, c.Resolve(&info))
+		require.Equal(t, "root", info.Scope)
+	})
+}
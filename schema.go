@@ -1,8 +1,10 @@
 package di
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sort"
 )
 
 // schema is a dependency injection schema.
@@ -11,6 +13,33 @@ type schema interface {
 	find(t reflect.Type, tags Tags) (*node, error)
 	// register cleanup
 	cleanup(cleanup func())
+	// context returns context of types construction
+	context() context.Context
+	// warn registers non-fatal finding
+	warn(w Warning)
+	// scope returns schema where scoped values are cached
+	scope() *defaultSchema
+	// authorize checks that consumer can obtain target
+	authorize(consumer *node, target *node) error
+}
+
+// namespacedSchema is a schema that prefers nodes with names qualified by prefix.
+type namespacedSchema struct {
+	schema
+	prefix string
+}
+
+// find finds node with qualified name, if it does not exist finds node with tags as is.
+func (s namespacedSchema) find(t reflect.Type, tags Tags) (*node, error) {
+	qualified := Tags{}
+	for k, v := range tags {
+		qualified[k] = v
+	}
+	qualified["name"] = qualifiedName(s.prefix, tags["name"])
+	if n, err := s.schema.find(t, qualified); err == nil {
+		return n, nil
+	}
+	return s.schema.find(t, tags)
 }
 
 // schema is a dependency injection schema.
@@ -18,16 +47,78 @@ type defaultSchema struct {
 	parents  []*defaultSchema
 	nodes    map[reflect.Type][]*node
 	cleanups []func()
+	// version increments on each registration
+	version int
+	// ctx is a context of types construction
+	ctx context.Context
+	// warnings is a non-fatal findings in order of occurrence
+	warnings []Warning
+	// fallback is true if own definitions preferred and parents used only when type
+	// not found
+	fallback bool
+	// values is a cache of scoped values
+	values map[*node]reflect.Value
+	// authorizers is a resolution policies
+	authorizers []AuthorizeFunc
+	// middlewares is a resolution middlewares
+	middlewares []Middleware
+	// tagName is a struct tag key of injectable fields
+	tagName string
+	// history is a recorder of operations, nil if recording disabled
+	history *history
+	// cycles is a strategy of dependency cycles breaking, nil if default
+	cycles CycleStrategy
+	// name is a name of scope
+	name string
+	// profiles is an active profiles
+	profiles []string
+	// eager is true while eager initialization in progress
+	eager bool
+	// shadowHooks is a hooks of shadow mismatches
+	shadowHooks []func(m ShadowMismatch)
+	// implementers is a nodes which interfaces bound on lookup
+	implementers []*node
 }
 
+// cleanup registers cleanup. Each cleanup runs once, even it called by Container.Reset()
+// and Container.Cleanup().
 func (s *defaultSchema) cleanup(cleanup func()) {
-	s.cleanups = append(s.cleanups, cleanup)
+	if cleanup == nil {
+		return
+	}
+	done := false
+	s.cleanups = append(s.cleanups, func() {
+		if done {
+			return
+		}
+		done = true
+		cleanup()
+	})
+}
+
+func (s *defaultSchema) context() context.Context {
+	return s.ctx
+}
+
+func (s *defaultSchema) scope() *defaultSchema {
+	return s
+}
+
+func (s *defaultSchema) warn(w Warning) {
+	for _, cur := range s.warnings {
+		if cur == w {
+			return
+		}
+	}
+	s.warnings = append(s.warnings, w)
 }
 
 // newDefaultSchema creates new dependency injection schema.
 func newDefaultSchema() *defaultSchema {
 	return &defaultSchema{
-		nodes: map[reflect.Type][]*node{},
+		nodes:   map[reflect.Type][]*node{},
+		ctx:     context.Background(),
+		tagName: defaultTagName,
 	}
 }
 
@@ -35,6 +126,8 @@ func newDefaultSchema() *defaultSchema {
 // type []<type> for group.
 func (s *defaultSchema) register(n *node) {
 	defer tracer.Trace("Register %s", n)
+	s.version++
+	n.owner = s
 	if _, ok := s.nodes[n.rt]; !ok {
 		s.nodes[n.rt] = []*node{n}
 		return
@@ -42,59 +135,230 @@ func (s *defaultSchema) register(n *node) {
 	s.nodes[n.rt] = append(s.nodes[n.rt], n)
 }
 
-// used depth-first topological sort algorithm
+// used depth-first topological sort algorithm. Successfully prepared nodes are not
+// prepared again until schema or its ancestors change.
 func (s *defaultSchema) prepare(n *node) error {
-	var marks = map[*node]int{}
+	revision := s.revision()
+	if n.prepared.schema == s && n.prepared.revision == revision {
+		return nil
+	}
+	marks := acquireMarks()
+	defer releaseMarks(marks)
 	if err := visit(s, n, marks); err != nil {
 		return err
 	}
+	for m, mark := range marks {
+		if mark == permanent {
+			m.prepared.schema = s
+			m.prepared.revision = revision
+		}
+	}
 	return nil
 }
 
+// revision returns revision of schema with its ancestors.
+func (s *defaultSchema) revision() int {
+	revision := s.version
+	for _, parent := range s.parents {
+		revision += parent.revision()
+	}
+	return revision
+}
+
+// query is a parameters of node search.
+type query struct {
+	// tags that node must contain
+	tags Tags
+	// selector that node tags must satisfy
+	selector TagSelector
+	// exact requires unique match even for group types
+	exact bool
+	// filter is a tags that group members must contain
+	filter Tags
+}
+
+// match returns nodes that matches query. Default nodes matched only if there are no other
+// nodes, unless query requires exact match.
+func (q query) match(nodes []*node) []*node {
+	matched := make([]*node, 0, 1)
+	defaults := 0
+	for _, n := range nodes {
+		if n.tags.match(q.tags) && n.tags.match(q.filter) && n.tags.Match(q.selector) {
+			matched = append(matched, n)
+			if n.byDefault {
+				defaults++
+			}
+		}
+	}
+	if defaults == 0 || defaults == len(matched) || q.exact {
+		return matched
+	}
+	kept := matched[:0]
+	for _, n := range matched {
+		if !n.byDefault {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
+
+// primaryNode returns the only primary node of nodes.
+func primaryNode(nodes []*node) (*node, bool) {
+	var primary *node
+	for _, n := range nodes {
+		if !n.primary {
+			continue
+		}
+		if primary != nil {
+			return nil, false
+		}
+		primary = n
+	}
+	return primary, primary != nil
+}
+
+// String is a string representation of query.
+func (q query) String() string {
+	s := q.tags.String()
+	if selector := q.selector.String(); selector != "" {
+		s += "{" + selector + "}"
+	}
+	if len(q.filter) > 0 {
+		s += "|" + q.filter.String()
+	}
+	return s
+}
+
 // find finds provideFunc by its reflect.Type and Tags.
 func (s *defaultSchema) find(t reflect.Type, tags Tags) (*node, error) {
+	return s.search(t, query{tags: tags})
+}
+
+// search finds node by its reflect.Type and query.
+func (s *defaultSchema) search(t reflect.Type, q query) (*node, error) {
+	if len(q.filter) > 0 {
+		return s.filter(t, q)
+	}
+	s.implement(t)
+	if s.fallback {
+		if matched := q.match(s.nodes[t]); len(matched) == 1 {
+			return matched[0], nil
+		}
+	}
 	nodes, ok := s.list(t)
 	// type found
 	if ok {
-		matched := matchTags(nodes, tags)
+		matched := q.match(nodes)
 		if len(matched) == 0 {
-			return nil, fmt.Errorf("type %s%s %w", t, tags, ErrTypeNotExists)
+			return nil, resolveError(t, q, fmt.Errorf("type %s%s %w%s", t, q, ErrTypeNotExists, s.suggest(t)))
 		}
 		if len(matched) > 1 {
-			return nil, fmt.Errorf("multiple definitions of %s%s, maybe you need to use group type: []%s%s", t, tags, t, tags)
+			if primary, ok := primaryNode(matched); ok && !q.exact {
+				return primary, nil
+			}
+			return nil, resolveError(t, q, fmt.Errorf("%w of %s%s, maybe you need to use group type: []%s%s", ErrAmbiguousType, t, q, t, q))
 		}
 		return matched[0], nil
 	}
+	if t == resolutionInfoType && len(q.tags) == 0 {
+		return s.resolutionInfo(), nil
+	}
+	if isLazy(t) {
+		return s.lazy(t, q)
+	}
+	if isProvider(t) {
+		return s.provider(t, q)
+	}
+	if isKeyed(t) {
+		return s.keyed(t, q)
+	}
 	// if not a group and not have di.Inject
-	if t.Kind() != reflect.Slice && !canInject(t) {
-		return nil, fmt.Errorf("type %s%s %w", t, tags, ErrTypeNotExists)
+	if t.Kind() != reflect.Slice && !isNamedGroup(t) && !canInject(t) {
+		return nil, resolveError(t, q, fmt.Errorf("type %s%s %w%s", t, q, ErrTypeNotExists, s.suggest(t)))
 	}
 	if canInject(t) {
 		node := &node{
 			compiler: newTypeCompiler(t),
 			rt:       t,
 			rv:       new(reflect.Value),
+			implicit: true,
 		}
 		// save node for future use
 		s.nodes[t] = append(s.nodes[t], node)
 		return node, nil
 	}
-	return s.group(t, tags)
+	if isNamedGroup(t) {
+		return s.namedGroup(t, q)
+	}
+	return s.group(t, q)
 }
 
-func (s *defaultSchema) group(t reflect.Type, tags Tags) (*node, error) {
+func (s *defaultSchema) group(t reflect.Type, q query) (*node, error) {
 	group, ok := s.list(t.Elem())
 	if !ok {
-		return nil, fmt.Errorf("type %s%s %w", t, tags, ErrTypeNotExists)
+		return nil, resolveError(t, q, fmt.Errorf("type %s%s %w", t, q, ErrTypeNotExists))
+	}
+	matched := q.match(group)
+	// filtered group can be empty
+	if len(matched) == 0 && len(q.filter) == 0 {
+		return nil, resolveError(t, q, fmt.Errorf("type %s%s %w", t, q, ErrTypeNotExists))
 	}
-	matched := matchTags(group, tags)
-	if len(matched) == 0 {
-		return nil, fmt.Errorf("type %s%s %w", t, tags, ErrTypeNotExists)
+	if q.exact && len(matched) > 1 {
+		return nil, resolveError(t, q, fmt.Errorf("%w of %s%s, exact match required", ErrAmbiguousType, t.Elem(), q))
 	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].order < matched[j].order
+	})
 	node := &node{
 		compiler: newGroupCompiler(t, matched),
 		rt:       t,
-		tags:     tags,
+		tags:     q.tags,
+		rv:       new(reflect.Value),
+	}
+	return node, nil
+}
+
+// filter creates node of group with members filtered by query. Definitions of group type
+// itself are not matched.
+func (s *defaultSchema) filter(t reflect.Type, q query) (*node, error) {
+	if t.Kind() != reflect.Slice && !isNamedGroup(t) {
+		return nil, resolveError(t, q, fmt.Errorf("%s%s: tags filter can be used with groups only", t, q))
+	}
+	if _, ok := s.list(t.Elem()); !ok {
+		return nil, resolveError(t, q, fmt.Errorf("type %s%s %w", t, q, ErrTypeNotExists))
+	}
+	if isNamedGroup(t) {
+		return s.namedGroup(t, q)
+	}
+	return s.group(t, q)
+}
+
+// namedGroup creates node of map with group members keyed by name tag. Members without name
+// are not included.
+func (s *defaultSchema) namedGroup(t reflect.Type, q query) (*node, error) {
+	group, _ := s.list(t.Elem())
+	var matched []*node
+	var names []string
+	for _, n := range q.match(group) {
+		name, ok := n.tags["name"]
+		if !ok {
+			continue
+		}
+		for _, cur := range names {
+			if cur == name {
+				return nil, resolveError(t, q, fmt.Errorf("%w of %s with name %s", ErrAmbiguousType, t.Elem(), name))
+			}
+		}
+		matched = append(matched, n)
+		names = append(names, name)
+	}
+	if len(matched) == 0 && len(q.filter) == 0 {
+		return nil, resolveError(t, q, fmt.Errorf("type %s%s %w", t, q, ErrTypeNotExists))
+	}
+	node := &node{
+		compiler: newNamedGroupCompiler(t, matched, names),
+		rt:       t,
+		tags:     q.tags,
 		rv:       new(reflect.Value),
 	}
 	return node, nil
@@ -102,6 +366,7 @@ func (s *defaultSchema) group(t reflect.Type, tags Tags) (*node, error) {
 
 // list lists all the nodes of its reflect.Type
 func (s *defaultSchema) list(t reflect.Type) (nodes []*node, ok bool) {
+	s.implement(t)
 	for _, parent := range s.parents {
 		if n, o := parent.list(t); o {
 			nodes = append(nodes, n...)
@@ -115,6 +380,17 @@ func (s *defaultSchema) list(t reflect.Type) (nodes []*node, ok bool) {
 	return nodes, ok
 }
 
+// all returns all the nodes of the schema and its ancestors.
+func (s *defaultSchema) all() (nodes []*node) {
+	for _, parent := range s.parents {
+		nodes = append(nodes, parent.all()...)
+	}
+	for _, n := range s.nodes {
+		nodes = append(nodes, n...)
+	}
+	return nodes
+}
+
 // isAncestor returns true if a
 func (s *defaultSchema) isAncestor(a *defaultSchema) bool {
 	for _, parent := range s.parents {
@@ -139,5 +415,6 @@ func (s *defaultSchema) addParent(parent *defaultSchema) error {
 		return fmt.Errorf("parent already chained")
 	}
 	s.parents = append(s.parents, parent)
+	s.version++
 	return nil
 }
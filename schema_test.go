@@ -0,0 +1,47 @@
+package di
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSchema_prepare(t *testing.T) {
+	t.Run("prepared node not prepared again until schema changed", func(t *testing.T) {
+		s := newDefaultSchema()
+		ctor, err := newConstructorNode(func(i int32) int64 { return int64(i) })
+		require.NoError(t, err)
+		s.register(ctor)
+		require.Error(t, s.prepare(ctor))
+		dep, err := newConstructorNode(func() int32 { return 0 })
+		require.NoError(t, err)
+		s.register(dep)
+		require.NoError(t, s.prepare(ctor))
+		require.Equal(t, s.revision(), ctor.prepared.revision)
+		require.Equal(t, s.revision(), dep.prepared.revision)
+		// remove dependency without changing revision, cached result used
+		delete(s.nodes, reflect.TypeOf(int32(0)))
+		require.NoError(t, s.prepare(ctor))
+		// register changes revision, node checked again
+		other, err := newConstructorNode(func() string { return "" })
+		require.NoError(t, err)
+		s.register(other)
+		require.Error(t, s.prepare(ctor))
+	})
+
+	t.Run("parent changes invalidate prepared nodes", func(t *testing.T) {
+		parent := newDefaultSchema()
+		s := newDefaultSchema()
+		require.NoError(t, s.addParent(parent))
+		n, err := newConstructorNode(func() int32 { return 0 })
+		require.NoError(t, err)
+		s.register(n)
+		require.NoError(t, s.prepare(n))
+		revision := s.revision()
+		other, err := newConstructorNode(func() string { return "" })
+		require.NoError(t, err)
+		parent.register(other)
+		require.NotEqual(t, revision, s.revision())
+	})
+}
@@ -0,0 +1,73 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// selection is a definition of interface which implementation selected on first resolve.
+type selection struct {
+	target     Interface
+	selector   Invocation
+	candidates map[string]Constructor
+}
+
+// Select returns container option that provides interface which implementation selected on
+// first resolve. The selector is a function that returns key of implementation, its arguments
+// resolved from container. Only selected candidate is constructed, but dependencies of all
+// candidates are checked like dependencies of other definitions.
+//
+//	container, err := di.New(
+//		di.Provide(LoadConfig),
+//		di.Select(new(Storage), func(config *Config) string { return config.Storage }, map[string]di.Constructor{
+//			"s3": NewS3Storage,
+//			"fs": NewFileStorage,
+//		}),
+//	)
+func Select(target Interface, selector Invocation, candidates map[string]Constructor, options ...ProvideOption) Option {
+	frame := stacktrace(0)
+	return option(func(c *diopts) {
+		c.provides = append(c.provides, provideOptions{
+			frame,
+			&selection{
+				target:     target,
+				selector:   selector,
+				candidates: candidates,
+			},
+			options,
+		})
+	})
+}
+
+// newSelectNode creates node of selection.
+func newSelectNode(sel *selection) (*node, error) {
+	i, err := inspectInterfacePointer(sel.target)
+	if err != nil {
+		return nil, err
+	}
+	fn, valid := inspectFunction(sel.selector)
+	if !valid || !validateResultInvocation(fn, reflect.TypeOf("")) {
+		return nil, fmt.Errorf("%w, selector must return string, got %s", ErrInvalidInvocation, reflect.TypeOf(sel.selector))
+	}
+	cmp := &selectCompiler{
+		rt:         i.Type,
+		selector:   fn,
+		candidates: map[string]*node{},
+	}
+	for key, constructor := range sel.candidates {
+		candidate, err := newConstructorNode(constructor)
+		if err != nil {
+			return nil, fmt.Errorf("candidate %q: %w", key, err)
+		}
+		if !candidate.rt.Implements(i.Type) {
+			return nil, fmt.Errorf("candidate %q: %s not implement %s", key, candidate.rt, i.Type)
+		}
+		cmp.candidates[key] = candidate
+	}
+	return &node{
+		rv:       new(reflect.Value),
+		rt:       i.Type,
+		tags:     Tags{},
+		compiler: cmp,
+	}, nil
+}
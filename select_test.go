@@ -0,0 +1,101 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestSelect(t *testing.T) {
+	t.Run("only selected candidate constructed", func(t *testing.T) {
+		var built []string
+		c, err := di.New(
+			di.Const("mux", "handler"),
+			di.Select(new(http.Handler), func(kind string) string { return kind }, map[string]di.Constructor{
+				"mux": func() *http.ServeMux {
+					built = append(built, "mux")
+					return http.NewServeMux()
+				},
+				"not found": func() http.HandlerFunc {
+					built = append(built, "not found")
+					return http.NotFoundHandler().(http.HandlerFunc)
+				},
+			}),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		require.NoError(t, c.Resolve(&handler))
+		require.IsType(t, &http.ServeMux{}, handler)
+		var again http.Handler
+		require.NoError(t, c.Resolve(&again))
+		require.Same(t, handler, again)
+		require.Equal(t, []string{"mux"}, built)
+	})
+
+	t.Run("selector arguments resolved from container", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{Addr: ":8080"} }),
+			di.Select(new(http.Handler), func(server *http.Server) (string, error) { return server.Addr, nil }, map[string]di.Constructor{
+				":8080": http.NewServeMux,
+			}),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		require.NoError(t, c.Resolve(&handler))
+		require.IsType(t, &http.ServeMux{}, handler)
+	})
+
+	t.Run("unknown selection", func(t *testing.T) {
+		c, err := di.New(
+			di.Select(new(http.Handler), func() string { return "unknown" }, map[string]di.Constructor{
+				"mux":    http.NewServeMux,
+				"server": func() *http.Server { return &http.Server{} },
+			}),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "select_test.go:")
+		require.Contains(t, err.Error(), `candidate "server": *http.Server not implement http.Handler`)
+		c, err = di.New(
+			di.Select(new(http.Handler), func() string { return "unknown" }, map[string]di.Constructor{
+				"mux":       http.NewServeMux,
+				"not found": http.NotFoundHandler,
+			}),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		err = c.Resolve(&handler)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `unknown selection "unknown", expected one of mux, not found`)
+	})
+
+	t.Run("invalid selector", func(t *testing.T) {
+		_, err := di.New(
+			di.Select(new(http.Handler), func() int { return 1 }, map[string]di.Constructor{
+				"mux": http.NewServeMux,
+			}),
+		)
+		require.True(t, errors.Is(err, di.ErrInvalidInvocation))
+		require.Contains(t, err.Error(), "select_test.go:")
+	})
+
+	t.Run("dependencies of all candidates checked", func(t *testing.T) {
+		c, err := di.New(
+			di.Select(new(http.Handler), func() string { return "mux" }, map[string]di.Constructor{
+				"mux":    http.NewServeMux,
+				"server": func(server *http.Server) http.Handler { return server.Handler },
+			}),
+		)
+		require.NoError(t, err)
+		var handler http.Handler
+		err = c.Resolve(&handler)
+		require.True(t, errors.Is(err, di.ErrTypeNotExists))
+		require.Contains(t, err.Error(), "*http.Server")
+		err = c.Verify()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "*http.Server")
+	})
+}
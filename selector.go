@@ -0,0 +1,195 @@
+package di
+
+import (
+	"fmt"
+	"strings"
+)
+
+// selectorOperator is an operator of tag selector requirement.
+type selectorOperator string
+
+const (
+	selectorEquals       selectorOperator = "="
+	selectorNotEquals    selectorOperator = "!="
+	selectorIn           selectorOperator = "in"
+	selectorNotIn        selectorOperator = "notin"
+	selectorExists       selectorOperator = "exists"
+	selectorDoesNotExist selectorOperator = "!"
+)
+
+// requirement is a single condition of tag selector.
+type requirement struct {
+	key      string
+	operator selectorOperator
+	values   []string
+}
+
+// matches checks that tags satisfy requirement.
+func (r requirement) matches(tags Tags) bool {
+	v, ok := tags[r.key]
+	switch r.operator {
+	case selectorEquals:
+		return ok && v == r.values[0]
+	case selectorNotEquals:
+		return !ok || v != r.values[0]
+	case selectorIn:
+		return ok && contains(r.values, v)
+	case selectorNotIn:
+		return !ok || !contains(r.values, v)
+	case selectorExists:
+		return ok
+	case selectorDoesNotExist:
+		return !ok
+	}
+	bug()
+	return false
+}
+
+// String is a string representation of requirement.
+func (r requirement) String() string {
+	switch r.operator {
+	case selectorEquals, selectorNotEquals:
+		return r.key + string(r.operator) + r.values[0]
+	case selectorIn, selectorNotIn:
+		return r.key + " " + string(r.operator) + " (" + strings.Join(r.values, ",") + ")"
+	case selectorExists:
+		return r.key
+	case selectorDoesNotExist:
+		return "!" + r.key
+	}
+	bug()
+	return ""
+}
+
+// TagSelector is a parsed tag selector. It selects tags that satisfy all of its requirements.
+// See ParseTagSelector() for syntax.
+type TagSelector struct {
+	requirements []requirement
+}
+
+// ParseTagSelector parses tag selector. Selector is a comma separated list of requirements:
+//
+//	env=prod              key env exists and equals prod (also env==prod)
+//	env!=prod             key env does not exist or does not equal prod
+//	region in (eu,us)     key region exists and equals eu or us
+//	region notin (eu,us)  key region does not exist or equals neither eu nor us
+//	deprecated            key deprecated exists
+//	!deprecated           key deprecated does not exist
+//
+// Example: "env=prod,region in (eu,us),!deprecated".
+func ParseTagSelector(selector string) (TagSelector, error) {
+	var result TagSelector
+	parts, err := splitSelector(selector)
+	if err != nil {
+		return TagSelector{}, err
+	}
+	for _, part := range parts {
+		r, err := parseRequirement(part)
+		if err != nil {
+			return TagSelector{}, fmt.Errorf("invalid tag selector %q: %w", selector, err)
+		}
+		result.requirements = append(result.requirements, r)
+	}
+	return result, nil
+}
+
+// Matches checks that tags satisfy all selector requirements. Empty selector matches any tags.
+func (s TagSelector) Matches(tags Tags) bool {
+	for _, r := range s.requirements {
+		if !r.matches(tags) {
+			return false
+		}
+	}
+	return true
+}
+
+// String is a string representation of selector.
+func (s TagSelector) String() string {
+	parts := make([]string, 0, len(s.requirements))
+	for _, r := range s.requirements {
+		parts = append(parts, r.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// Match checks that tags satisfy selector.
+func (t Tags) Match(selector TagSelector) bool {
+	return selector.Matches(t)
+}
+
+// splitSelector splits selector by commas that not inside parentheses.
+func splitSelector(selector string) ([]string, error) {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid tag selector %q: unexpected )", selector)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid tag selector %q: unclosed (", selector)
+	}
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+	return append(parts, selector[start:]), nil
+}
+
+// parseRequirement parses single requirement of selector.
+func parseRequirement(s string) (requirement, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return requirement{}, fmt.Errorf("empty requirement")
+	}
+	if i := strings.Index(s, "("); i >= 0 {
+		if !strings.HasSuffix(s, ")") {
+			return requirement{}, fmt.Errorf("requirement %q: expected ) at the end", s)
+		}
+		fields := strings.Fields(s[:i])
+		if len(fields) != 2 || (fields[1] != string(selectorIn) && fields[1] != string(selectorNotIn)) {
+			return requirement{}, fmt.Errorf("requirement %q: expected <key> in|notin (<values>)", s)
+		}
+		var values []string
+		for _, v := range strings.Split(s[i+1:len(s)-1], ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+		return requirement{key: fields[0], operator: selectorOperator(fields[1]), values: values}, validateTagKey(fields[0])
+	}
+	if strings.HasPrefix(s, "!") && !strings.Contains(s, "=") {
+		key := strings.TrimSpace(s[1:])
+		return requirement{key: key, operator: selectorDoesNotExist}, validateTagKey(key)
+	}
+	for _, op := range []string{"!=", "==", "="} {
+		if i := strings.Index(s, op); i >= 0 {
+			key := strings.TrimSpace(s[:i])
+			value := strings.TrimSpace(s[i+len(op):])
+			operator := selectorEquals
+			if op == "!=" {
+				operator = selectorNotEquals
+			}
+			return requirement{key: key, operator: operator, values: []string{value}}, validateTagKey(key)
+		}
+	}
+	return requirement{key: s, operator: selectorExists}, validateTagKey(s)
+}
+
+// contains checks that values contains v.
+func contains(values []string, v string) bool {
+	for _, cur := range values {
+		if cur == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package di_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestParseTagSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		tags     di.Tags
+		matches  bool
+	}{
+		{"", di.Tags{"env": "prod"}, true},
+		{"env=prod", di.Tags{"env": "prod"}, true},
+		{"env==prod", di.Tags{"env": "dev"}, false},
+		{"env!=prod", di.Tags{}, true},
+		{"env!=prod", di.Tags{"env": "prod"}, false},
+		{"region in (eu, us)", di.Tags{"region": "us"}, true},
+		{"region in (eu,us)", di.Tags{"region": "cn"}, false},
+		{"region notin (eu,us)", di.Tags{"region": "cn"}, true},
+		{"deprecated", di.Tags{"deprecated": "true"}, true},
+		{"!deprecated", di.Tags{"deprecated": "true"}, false},
+		{"env=prod,region in (eu,us),!deprecated", di.Tags{"env": "prod", "region": "eu"}, true},
+		{"env=prod,region in (eu,us),!deprecated", di.Tags{"env": "prod", "region": "eu", "deprecated": ""}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := di.ParseTagSelector(tt.selector)
+			require.NoError(t, err)
+			require.Equal(t, tt.matches, tt.tags.Match(selector))
+		})
+	}
+
+	t.Run("string representation", func(t *testing.T) {
+		selector, err := di.ParseTagSelector("env==prod, region in (eu, us),!deprecated")
+		require.NoError(t, err)
+		require.Equal(t, "env=prod,region in (eu,us),!deprecated", selector.String())
+	})
+
+	for _, invalid := range []string{"env=prod,", "region in (eu", "region of (eu)", "a b=c"} {
+		t.Run("invalid "+invalid, func(t *testing.T) {
+			_, err := di.ParseTagSelector(invalid)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestTags_Merge(t *testing.T) {
+	tags := di.Tags{"env": "prod", "region": "eu"}
+	merged := tags.Merge(di.Tags{"region": "us"}, di.Tags{"tier": "cache"})
+	require.Equal(t, di.Tags{"env": "prod", "region": "us", "tier": "cache"}, merged)
+	require.Equal(t, di.Tags{"env": "prod", "region": "eu"}, tags)
+}
+
+func TestContainer_ProvideInvalidTags(t *testing.T) {
+	c, err := di.New()
+	require.NoError(t, err)
+	err = c.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"in valid": "true"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "selector_test.go:")
+	require.Contains(t, err.Error(), `: invalid tag key "in valid": contains one of reserved characters`)
+	err = c.ProvideValue(&http.Server{}, di.Tags{"": "true"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), ": invalid tag key: empty")
+}
+
+func TestSelector(t *testing.T) {
+	newContainer := func(t *testing.T) *di.Container {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{Addr: "cache"} }, di.Tags{"tier": "cache"}),
+			di.Provide(func() *http.Server { return &http.Server{Addr: "old-cache"} }, di.Tags{"tier": "cache", "deprecated": "true"}),
+			di.Provide(func() *http.Server { return &http.Server{Addr: "db"} }, di.Tags{"tier": "db"}),
+		)
+		require.NoError(t, err)
+		return c
+	}
+
+	t.Run("resolve with selector", func(t *testing.T) {
+		c := newContainer(t)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server, di.Selector("tier=cache,!deprecated")))
+		require.Equal(t, "cache", server.Addr)
+	})
+
+	t.Run("resolve group with selector", func(t *testing.T) {
+		c := newContainer(t)
+		var servers []*http.Server
+		require.NoError(t, c.Resolve(&servers, di.Selector("tier in (cache,db),!deprecated")))
+		require.Len(t, servers, 2)
+	})
+
+	t.Run("iterate with selector", func(t *testing.T) {
+		c := newContainer(t)
+		var addrs []string
+		err := c.Iterate(new([]*http.Server), func(tags di.Tags, value di.ValueFunc) error {
+			v, err := value()
+			if err != nil {
+				return err
+			}
+			addrs = append(addrs, v.(*http.Server).Addr)
+			return nil
+		}, di.Selector("deprecated"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"old-cache"}, addrs)
+	})
+
+	t.Run("not matched selector cause error", func(t *testing.T) {
+		c := newContainer(t)
+		var server *http.Server
+		err := c.Resolve(&server, di.Selector("tier=queue"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type *http.Server{tier=queue} not exists in the container")
+	})
+
+	t.Run("invalid selector cause error", func(t *testing.T) {
+		c := newContainer(t)
+		var server *http.Server
+		err := c.Resolve(&server, di.Selector("tier in (cache"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `invalid tag selector "tier in (cache": unclosed (`)
+	})
+}
@@ -0,0 +1,116 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Setters returns provide option that makes container call setter methods of constructed
+// instance with resolved arguments. Setters are exported methods with Set prefix, at least one
+// argument and optional error result. Useful for third-party types that can't embed di.Inject.
+//
+//	container, err := di.New(
+//		di.Provide(NewLogger),
+//		di.Provide(thirdparty.NewClient, di.Setters()), // calls client.SetLogger(logger)
+//	)
+func Setters() ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.AllSetters = true
+	})
+}
+
+// Setter returns provide option that makes container call method of constructed instance with
+// resolved arguments. The method must have at least one argument and optional error result.
+//
+//	container, err := di.New(
+//		di.Provide(NewLogger),
+//		di.Provide(thirdparty.NewClient, di.Setter("UseLogger")),
+//	)
+func Setter(method string) ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.Setters = append(params.Setters, method)
+	})
+}
+
+// setter is a method of instance that called after construction.
+type setter struct {
+	name string
+	args []reflect.Type
+}
+
+// inspectSetters returns setters of type t specified by provide params.
+func inspectSetters(t reflect.Type, params ProvideParams) ([]setter, error) {
+	var setters []setter
+	if params.AllSetters {
+		for i := 0; i < t.NumMethod(); i++ {
+			m := t.Method(i)
+			if !strings.HasPrefix(m.Name, "Set") {
+				continue
+			}
+			if args, ok := setterArgs(t, m); ok {
+				setters = append(setters, setter{name: m.Name, args: args})
+			}
+		}
+	}
+	for _, name := range params.Setters {
+		m, ok := t.MethodByName(name)
+		if !ok {
+			return nil, fmt.Errorf("%s has no method %s", t, name)
+		}
+		args, ok := setterArgs(t, m)
+		if !ok {
+			return nil, fmt.Errorf("%s.%s must have arguments and optional error result, got %s", t, name, m.Type)
+		}
+		setters = append(setters, setter{name: name, args: args})
+	}
+	return setters, nil
+}
+
+// setterArgs returns argument types of setter method m of type t. Methods of non-interface
+// types have receiver as first argument.
+func setterArgs(t reflect.Type, m reflect.Method) (args []reflect.Type, ok bool) {
+	offset := 1
+	if t.Kind() == reflect.Interface {
+		offset = 0
+	}
+	for i := offset; i < m.Type.NumIn(); i++ {
+		args = append(args, m.Type.In(i))
+	}
+	if len(args) == 0 || m.Type.IsVariadic() {
+		return nil, false
+	}
+	switch {
+	case m.Type.NumOut() == 0:
+	case m.Type.NumOut() == 1 && m.Type.Out(0) == errorInterface:
+	default:
+		return nil, false
+	}
+	return args, true
+}
+
+// callSetters calls setters of consumer value with arguments found by lookup.
+func callSetters(s schema, lookup schema, consumer *node, rv reflect.Value) error {
+	for _, setter := range consumer.setters {
+		args := make([]reflect.Value, 0, len(setter.args))
+		for _, arg := range setter.args {
+			node, err := lookup.find(arg, Tags{})
+			if err != nil {
+				return err
+			}
+			if err := s.authorize(consumer, node); err != nil {
+				return err
+			}
+			v, err := node.Value(s)
+			if err != nil {
+				return fmt.Errorf("%s: %w", node, err)
+			}
+			args = append(args, v)
+		}
+		res := rv.MethodByName(setter.name).Call(args)
+		if len(res) == 1 && !res[0].IsNil() {
+			return fmt.Errorf("%s: %w", setter.name, res[0].Interface().(error))
+		}
+	}
+	return nil
+}
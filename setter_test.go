@@ -0,0 +1,105 @@
+package di_test
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+// client is a third-party type that exposes setters only.
+type client struct {
+	logger  *log.Logger
+	handler http.Handler
+	server  *http.Server
+}
+
+func (c *client) SetLogger(logger *log.Logger) { c.logger = logger }
+
+func (c *client) SetHandler(handler http.Handler) error {
+	if handler == nil {
+		return errors.New("nil handler")
+	}
+	c.handler = handler
+	return nil
+}
+
+func (c *client) UseServer(server *http.Server) { c.server = server }
+
+func (c *client) Settings() string { return "" }
+
+func TestSetters(t *testing.T) {
+	t.Run("setters called after construction", func(t *testing.T) {
+		logger := log.New(os.Stderr, "", 0)
+		mux := http.NewServeMux()
+		c, err := di.New(
+			di.ProvideValue(logger),
+			di.ProvideValue(mux, di.As(new(http.Handler))),
+			di.Provide(func() *client { return &client{} }, di.Setters()),
+		)
+		require.NoError(t, err)
+		var cl *client
+		require.NoError(t, c.Resolve(&cl))
+		require.Same(t, logger, cl.logger)
+		require.Equal(t, mux, cl.handler)
+		require.Nil(t, cl.server)
+	})
+
+	t.Run("named setter", func(t *testing.T) {
+		server := &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(server),
+			di.Provide(func() *client { return &client{} }, di.Setter("UseServer")),
+		)
+		require.NoError(t, err)
+		var cl *client
+		require.NoError(t, c.Resolve(&cl))
+		require.Same(t, server, cl.server)
+		require.Nil(t, cl.logger)
+	})
+
+	t.Run("setter error", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(log.New(os.Stderr, "", 0)),
+			di.Provide(func() http.Handler { return nil }),
+			di.Provide(func() *client { return &client{} }, di.Setters()),
+		)
+		require.NoError(t, err)
+		var cl *client
+		err = c.Resolve(&cl)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "SetHandler: nil handler")
+	})
+
+	t.Run("setter dependencies checked", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *client { return &client{} }, di.Setter("UseServer")),
+		)
+		require.NoError(t, err)
+		var cl *client
+		err = c.Resolve(&cl)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type *http.Server not exists in the container")
+		require.Equal(t, []di.TypeRef{{Type: reflect.TypeOf(&http.Server{}), Tags: di.Tags{}}}, c.Providers()[0].Dependencies)
+	})
+
+	t.Run("invalid setter cause error", func(t *testing.T) {
+		_, err := di.New(
+			di.Provide(func() *client { return &client{} }, di.Setter("Unknown")),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "setter_test.go:")
+		require.Contains(t, err.Error(), "*di_test.client has no method Unknown")
+		_, err = di.New(
+			di.Provide(func() *client { return &client{} }, di.Setter("Settings")),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "*di_test.client.Settings must have arguments and optional error result")
+	})
+}
@@ -0,0 +1,131 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// shadow is a definition that compares old and new implementations.
+type shadow struct {
+	old     Constructor
+	new     Constructor
+	compare func(a, b interface{}) error
+}
+
+// ShadowMismatch is a mismatch between old and new implementations of di.Shadow().
+type ShadowMismatch struct {
+	// Type is a provided type.
+	Type reflect.Type
+	// Old is an instance of old implementation.
+	Old Value
+	// New is an instance of new implementation, nil if its construction failed.
+	New Value
+	// Err is an error of comparison or construction error of new implementation.
+	Err error
+}
+
+// Shadow returns container option that provides result type of old constructor built by both
+// constructors. The old instance served to consumers, the new one only compared with it. Errors
+// of comparison and construction of new implementation reported to hooks registered by
+// di.OnShadowMismatch(), so implementation can be swapped safely after observation.
+//
+//	container, err := di.New(
+//		di.Shadow(NewLegacyPricing, NewPricing, func(a, b interface{}) error {
+//			if a.(*Pricing).Rate(order) != b.(*Pricing).Rate(order) {
+//				return fmt.Errorf("rate mismatch")
+//			}
+//			return nil
+//		}),
+//		di.OnShadowMismatch(func(m di.ShadowMismatch) {
+//			log.Printf("%s: %s", m.Type, m.Err)
+//		}),
+//	)
+func Shadow(oldCtor, newCtor Constructor, compare func(a, b interface{}) error, options ...ProvideOption) Option {
+	frame := stacktrace(0)
+	return option(func(c *diopts) {
+		c.provides = append(c.provides, provideOptions{
+			frame,
+			&shadow{
+				old:     oldCtor,
+				new:     newCtor,
+				compare: compare,
+			},
+			options,
+		})
+	})
+}
+
+// OnShadowMismatch returns container option that registers hook of di.Shadow() mismatches.
+// Child containers inherit hooks of container.
+func OnShadowMismatch(hook func(m ShadowMismatch)) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.schema.shadowHooks = append(c.schema.shadowHooks, hook)
+		})
+	})
+}
+
+// newShadowNode creates node of shadow.
+func newShadowNode(sh *shadow) (*node, error) {
+	oldNode, err := newConstructorNode(sh.old)
+	if err != nil {
+		return nil, fmt.Errorf("old: %w", err)
+	}
+	newNode, err := newConstructorNode(sh.new)
+	if err != nil {
+		return nil, fmt.Errorf("new: %w", err)
+	}
+	if !newNode.rt.AssignableTo(oldNode.rt) {
+		return nil, fmt.Errorf("new: %s not assignable to %s", newNode.rt, oldNode.rt)
+	}
+	if sh.compare == nil {
+		return nil, fmt.Errorf("compare function required")
+	}
+	// instances cached by shadow node itself
+	oldNode.lifetime = Transient
+	newNode.lifetime = Transient
+	return &node{
+		rv:   new(reflect.Value),
+		rt:   oldNode.rt,
+		tags: Tags{},
+		compiler: &shadowCompiler{
+			old:     oldNode,
+			new:     newNode,
+			compare: sh.compare,
+		},
+	}, nil
+}
+
+// shadowCompiler compiles old implementation and compares it with new one.
+type shadowCompiler struct {
+	old     *node
+	new     *node
+	compare func(a, b interface{}) error
+}
+
+func (c *shadowCompiler) deps(s schema) ([]*node, error) {
+	return []*node{c.old}, nil
+}
+
+func (c *shadowCompiler) compile(dependencies []reflect.Value, s schema) (reflect.Value, error) {
+	old := dependencies[0]
+	mismatch := ShadowMismatch{Type: c.old.rt, Old: old.Interface()}
+	rv, err := c.new.Value(s)
+	if err != nil {
+		mismatch.Err = err
+		s.scope().reportShadow(mismatch)
+		return old, nil
+	}
+	mismatch.New = rv.Interface()
+	if mismatch.Err = c.compare(mismatch.Old, mismatch.New); mismatch.Err != nil {
+		s.scope().reportShadow(mismatch)
+	}
+	return old, nil
+}
+
+// reportShadow calls shadow mismatch hooks.
+func (s *defaultSchema) reportShadow(m ShadowMismatch) {
+	for _, hook := range s.shadowHooks {
+		hook(m)
+	}
+}
@@ -0,0 +1,112 @@
+package di_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestShadow(t *testing.T) {
+	compareAddr := func(a, b interface{}) error {
+		if a.(*http.Server).Addr != b.(*http.Server).Addr {
+			return fmt.Errorf("addr %s != %s", a.(*http.Server).Addr, b.(*http.Server).Addr)
+		}
+		return nil
+	}
+
+	t.Run("old implementation served and mismatch reported", func(t *testing.T) {
+		var mismatches []di.ShadowMismatch
+		old := &http.Server{Addr: ":80"}
+		c, err := di.New(
+			di.Shadow(
+				func() *http.Server { return old },
+				func() *http.Server { return &http.Server{Addr: ":8080"} },
+				compareAddr,
+			),
+			di.OnShadowMismatch(func(m di.ShadowMismatch) {
+				mismatches = append(mismatches, m)
+			}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.Same(t, old, server)
+		require.Len(t, mismatches, 1)
+		require.Equal(t, "*http.Server", mismatches[0].Type.String())
+		require.Same(t, old, mismatches[0].Old)
+		require.EqualError(t, mismatches[0].Err, "addr :80 != :8080")
+		// singleton built once
+		require.NoError(t, c.Resolve(&server))
+		require.Len(t, mismatches, 1)
+	})
+
+	t.Run("equal implementations not reported", func(t *testing.T) {
+		var mismatches int
+		c, err := di.New(
+			di.Shadow(
+				func() *http.Server { return &http.Server{Addr: ":80"} },
+				func() *http.Server { return &http.Server{Addr: ":80"} },
+				compareAddr,
+			),
+			di.OnShadowMismatch(func(m di.ShadowMismatch) { mismatches++ }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, 0, mismatches)
+	})
+
+	t.Run("construction error of new implementation reported", func(t *testing.T) {
+		var mismatches []di.ShadowMismatch
+		c, err := di.New(
+			di.Shadow(
+				func() *http.Server { return &http.Server{Addr: ":80"} },
+				func() (*http.Server, error) { return nil, errors.New("new error") },
+				compareAddr,
+			),
+			di.OnShadowMismatch(func(m di.ShadowMismatch) {
+				mismatches = append(mismatches, m)
+			}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, ":80", server.Addr)
+		require.Len(t, mismatches, 1)
+		require.Nil(t, mismatches[0].New)
+		require.EqualError(t, mismatches[0].Err, "new error")
+	})
+
+	t.Run("dependencies of new implementation checked", func(t *testing.T) {
+		c, err := di.New(
+			di.Shadow(
+				func() *http.Server { return &http.Server{} },
+				func(mux *http.ServeMux) *http.Server { return &http.Server{Handler: mux} },
+				compareAddr,
+			),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type *http.ServeMux not exists in the container")
+	})
+
+	t.Run("incompatible implementations cause error", func(t *testing.T) {
+		_, err := di.New(
+			di.Shadow(
+				func() *http.Server { return &http.Server{} },
+				func() *http.Client { return &http.Client{} },
+				compareAddr,
+			),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "shadow_test.go:")
+		require.Contains(t, err.Error(), "new: *http.Client not assignable to *http.Server")
+	})
+}
@@ -0,0 +1,146 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// sharedRegistry is a process-level registry of shared instances.
+var sharedRegistry = struct {
+	sync.Mutex
+	entries map[string]*sharedEntry
+}{
+	entries: map[string]*sharedEntry{},
+}
+
+// sharedEntry is a shared instance with count of containers that use it.
+type sharedEntry struct {
+	rt      reflect.Type
+	value   reflect.Value
+	cleanup func()
+	refs    int
+	// mu guards construction of instance, it is not held with registry lock
+	mu    sync.Mutex
+	built bool
+}
+
+// Shared wraps constructor so that its instance is shared between containers by key. The first
+// container that resolves the type calls constructor, others get the same instance. The cleanup
+// of constructor will be called when the last container that uses instance is cleaned up.
+// It useful for heavyweight resources like embedded databases in tests.
+//
+//	func TestSomething(t *testing.T) {
+//		c, err := di.New(
+//			di.Provide(di.Shared("postgres", NewEmbeddedPostgres)),
+//		)
+//		require.NoError(t, err)
+//		defer c.Cleanup()
+//	}
+func Shared(key string, constructor Constructor) Constructor {
+	fn, ok := inspectFunction(constructor)
+	if !ok {
+		return constructor
+	}
+	typ := determineCtorType(fn)
+	if typ == ctorUnknown {
+		return constructor
+	}
+	var in []reflect.Type
+	for i := 0; i < fn.NumIn(); i++ {
+		in = append(in, fn.In(i))
+	}
+	rt := fn.Out(0)
+	out := []reflect.Type{rt, reflect.TypeOf(func() {}), errorInterface}
+	wrapper := reflect.MakeFunc(reflect.FuncOf(in, out, fn.IsVariadic()), func(args []reflect.Value) []reflect.Value {
+		value, release, err := acquireShared(key, rt, func() (reflect.Value, func(), error) {
+			var res funcResult
+			if fn.IsVariadic() {
+				res = fn.CallSlice(args)
+			} else {
+				res = fn.Call(args)
+			}
+			switch typ {
+			case ctorValueError:
+				return res.value(), nil, res.error(1)
+			case ctorValueCleanup:
+				return res.value(), res.cleanup(), nil
+			case ctorValueCleanupError:
+				return res.value(), res.cleanup(), res.error(2)
+			}
+			return res.value(), nil, nil
+		})
+		errValue := reflect.Zero(errorInterface)
+		if err != nil {
+			errValue = reflect.ValueOf(&err).Elem()
+			value = reflect.Zero(rt)
+		}
+		return []reflect.Value{value, reflect.ValueOf(release), errValue}
+	})
+	return wrapper.Interface()
+}
+
+// SharedRefs returns count of containers that use shared instances by keys.
+func SharedRefs() map[string]int {
+	sharedRegistry.Lock()
+	defer sharedRegistry.Unlock()
+	refs := make(map[string]int, len(sharedRegistry.entries))
+	for key, entry := range sharedRegistry.entries {
+		refs[key] = entry.refs
+	}
+	return refs
+}
+
+// acquireShared returns shared instance of key or creates it with build function. The instance
+// built outside of registry lock, so construction of instance does not block other keys and
+// can acquire other shared instances.
+func acquireShared(key string, rt reflect.Type, build func() (reflect.Value, func(), error)) (reflect.Value, func(), error) {
+	sharedRegistry.Lock()
+	entry, ok := sharedRegistry.entries[key]
+	if ok && entry.rt != rt {
+		sharedRegistry.Unlock()
+		return reflect.Value{}, nil, fmt.Errorf("shared key %s already used by %s", key, entry.rt)
+	}
+	if !ok {
+		entry = &sharedEntry{rt: rt}
+		sharedRegistry.entries[key] = entry
+	}
+	// reference keeps entry in registry while it is built
+	entry.refs++
+	sharedRegistry.Unlock()
+	entry.mu.Lock()
+	if !entry.built {
+		value, cleanup, err := build()
+		if err != nil {
+			entry.mu.Unlock()
+			releaseShared(key, entry)
+			return reflect.Value{}, nil, err
+		}
+		entry.value = value
+		entry.cleanup = cleanup
+		entry.built = true
+	}
+	entry.mu.Unlock()
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			releaseShared(key, entry)
+		})
+	}
+	return entry.value, release, nil
+}
+
+// releaseShared decrements entry references and cleanups it if no more references left.
+func releaseShared(key string, entry *sharedEntry) {
+	sharedRegistry.Lock()
+	entry.refs--
+	if entry.refs > 0 {
+		sharedRegistry.Unlock()
+		return
+	}
+	delete(sharedRegistry.entries, key)
+	sharedRegistry.Unlock()
+	if entry.cleanup != nil {
+		entry.cleanup()
+	}
+}
@@ -0,0 +1,152 @@
+package di_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestShared(t *testing.T) {
+	t.Run("instance shared between containers", func(t *testing.T) {
+		var calls, cleanups int
+		ctor := di.Shared("shared-server", func(mux *http.ServeMux) (*http.Server, func()) {
+			calls++
+			return &http.Server{Handler: mux}, func() { cleanups++ }
+		})
+		var servers []*http.Server
+		var containers []*di.Container
+		for i := 0; i < 2; i++ {
+			var server *http.Server
+			c, err := di.New(
+				di.Provide(http.NewServeMux),
+				di.Provide(ctor),
+				di.Resolve(&server),
+			)
+			require.NoError(t, err)
+			servers = append(servers, server)
+			containers = append(containers, c)
+		}
+		require.Equal(t, 1, calls)
+		require.Equal(t, fmt.Sprintf("%p", servers[0]), fmt.Sprintf("%p", servers[1]))
+		require.Equal(t, 2, di.SharedRefs()["shared-server"])
+		containers[0].Cleanup()
+		require.Equal(t, 0, cleanups)
+		require.Equal(t, 1, di.SharedRefs()["shared-server"])
+		containers[1].Cleanup()
+		require.Equal(t, 1, cleanups)
+		require.NotContains(t, di.SharedRefs(), "shared-server")
+		// new instance created after last cleanup
+		var server *http.Server
+		_, err := di.New(
+			di.Provide(http.NewServeMux),
+			di.Provide(ctor),
+			di.Resolve(&server),
+		)
+		require.NoError(t, err)
+		require.Equal(t, 2, calls)
+		_, ok := di.SharedRefs()["shared-error"]
+		require.False(t, ok)
+	})
+
+	t.Run("instance of parent not released by child", func(t *testing.T) {
+		var cleanups int
+		parent, err := di.New(
+			di.Provide(di.Shared("shared-parent", func() (*http.Server, func()) {
+				return &http.Server{}, func() { cleanups++ }
+			})),
+		)
+		require.NoError(t, err)
+		child, err := di.New()
+		require.NoError(t, err)
+		require.NoError(t, child.AddParent(parent))
+		var server *http.Server
+		require.NoError(t, child.Resolve(&server))
+		child.Cleanup()
+		require.Equal(t, 0, cleanups)
+		require.Equal(t, 1, di.SharedRefs()["shared-parent"])
+		parent.Cleanup()
+		require.Equal(t, 1, cleanups)
+	})
+
+	t.Run("constructor error not shared", func(t *testing.T) {
+		var calls int
+		ctor := di.Shared("shared-error", func() (*http.Server, error) {
+			calls++
+			return nil, errors.New("build failed")
+		})
+		for i := 0; i < 2; i++ {
+			c, err := di.New(
+				di.Provide(ctor),
+			)
+			require.NoError(t, err)
+			var server *http.Server
+			err = c.Resolve(&server)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "build failed")
+			c.Cleanup()
+		}
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("shared constructor acquires other shared instance", func(t *testing.T) {
+		type DB struct{}
+		type Cache struct{ db *DB }
+		c, err := di.New(
+			di.Provide(di.Shared("nested-db", func() *DB { return &DB{} })),
+			di.Provide(di.Shared("nested-cache", func(db di.Lazy[*DB]) (*Cache, error) {
+				d, err := db.Get()
+				if err != nil {
+					return nil, err
+				}
+				return &Cache{db: d}, nil
+			})),
+		)
+		require.NoError(t, err)
+		done := make(chan error, 1)
+		var cache *Cache
+		go func() {
+			done <- c.Resolve(&cache)
+		}()
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("resolve of nested shared instances blocked")
+		}
+		require.NotNil(t, cache.db)
+		require.Equal(t, 1, di.SharedRefs()["nested-db"])
+		c.Cleanup()
+		require.Equal(t, 0, di.SharedRefs()["nested-db"])
+		require.Equal(t, 0, di.SharedRefs()["nested-cache"])
+	})
+
+	t.Run("key used by another type cause error", func(t *testing.T) {
+		var server *http.Server
+		_, err := di.New(
+			di.Provide(di.Shared("shared-type", func() *http.Server { return &http.Server{} })),
+			di.Resolve(&server),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		_, err = di.New(
+			di.Provide(di.Shared("shared-type", http.NewServeMux)),
+			di.Resolve(&mux),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "shared key shared-type already used by *http.Server")
+	})
+
+	t.Run("invalid constructor cause error", func(t *testing.T) {
+		_, err := di.New(
+			di.Provide(di.Shared("shared-invalid", func() {})),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid constructor signature, got func()")
+	})
+}
@@ -0,0 +1,181 @@
+package di
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+)
+
+// Descriptor is a serialized option: name of module registered by di.RegisterModule() and its
+// parameters.
+//
+//	[
+//		{"module": "postgres", "params": {"dsn": "postgres://db/orders"}},
+//		{"module": "metrics"}
+//	]
+type Descriptor struct {
+	// Module is a name of module.
+	Module string `json:"module"`
+	// Params is a parameters of module.
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Source supplies descriptors of options, e.g. from file, embedded file system or remote
+// configuration service. See di.Include().
+type Source interface {
+	// Descriptors returns descriptors in order of applying.
+	Descriptors(ctx context.Context) ([]Descriptor, error)
+}
+
+// SourceFunc is an adapter to use function as Source.
+type SourceFunc func(ctx context.Context) ([]Descriptor, error)
+
+// Descriptors calls f(ctx).
+func (f SourceFunc) Descriptors(ctx context.Context) ([]Descriptor, error) {
+	return f(ctx)
+}
+
+// FileSource returns source that reads JSON array of descriptors from file.
+func FileSource(path string) Source {
+	return SourceFunc(func(ctx context.Context) ([]Descriptor, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return decodeDescriptors(bytes.NewReader(data))
+	})
+}
+
+// FSSource returns source that reads JSON array of descriptors from file of fsys, e.g. embed.FS.
+func FSSource(fsys fs.FS, path string) Source {
+	return SourceFunc(func(ctx context.Context) ([]Descriptor, error) {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		return decodeDescriptors(bytes.NewReader(data))
+	})
+}
+
+// HTTPSource returns source that reads JSON array of descriptors from response of GET request.
+// The request uses http.DefaultClient if client is nil.
+func HTTPSource(client *http.Client, url string) Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return SourceFunc(func(ctx context.Context) ([]Descriptor, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("get %s: unexpected status %s", url, resp.Status)
+		}
+		return decodeDescriptors(resp.Body)
+	})
+}
+
+// decodeDescriptors decodes JSON array of descriptors.
+func decodeDescriptors(r io.Reader) ([]Descriptor, error) {
+	var descriptors []Descriptor
+	if err := json.NewDecoder(r).Decode(&descriptors); err != nil {
+		return nil, fmt.Errorf("decode descriptors: %w", err)
+	}
+	return descriptors, nil
+}
+
+// ModuleFactory creates option of module from its parameters.
+type ModuleFactory func(params json.RawMessage) (Option, error)
+
+// ModuleOf returns module factory which parameters decoded into P. Unknown parameters cause
+// error.
+//
+//	type PostgresParams struct {
+//		DSN string `json:"dsn"`
+//	}
+//
+//	di.RegisterModule("postgres", di.ModuleOf(func(params PostgresParams) (di.Option, error) {
+//		return di.Provide(func() (*sql.DB, error) { return sql.Open("postgres", params.DSN) }), nil
+//	}))
+func ModuleOf[P any](fn func(params P) (Option, error)) ModuleFactory {
+	return func(raw json.RawMessage) (Option, error) {
+		var params P
+		if len(raw) > 0 {
+			decoder := json.NewDecoder(bytes.NewReader(raw))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&params); err != nil {
+				return nil, fmt.Errorf("decode params: %w", err)
+			}
+		}
+		return fn(params)
+	}
+}
+
+// RegisterModule returns container option that registers module factory by name. Descriptors
+// of di.Include() sources materialized by registered factories. Child containers inherit
+// modules of container.
+func RegisterModule(name string, factory ModuleFactory) Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			if c.modules == nil {
+				c.modules = map[string]ModuleFactory{}
+			}
+			c.modules[name] = factory
+		})
+	})
+}
+
+// Include returns container option that applies options described by source. The descriptors
+// read and materialized by modules registered with di.RegisterModule() on container creation,
+// so wiring can be pushed as data while factories keep it type safe.
+//
+//	container, err := di.New(
+//		di.RegisterModule("postgres", postgres.Module),
+//		di.RegisterModule("metrics", metrics.Module),
+//		di.Include(di.HTTPSource(nil, "https://config.example.com/wiring/orders.json")),
+//	)
+func Include(source Source) Option {
+	frame := stacktrace(0)
+	return option(func(c *diopts) {
+		c.includes = append(c.includes, includeOptions{
+			frame:  frame,
+			source: source,
+		})
+	})
+}
+
+// include applies options described by source qualified by namespaces of di.Instantiate().
+func (c *Container) include(source Source, namespaces []string) error {
+	descriptors, err := source.Descriptors(c.schema.ctx)
+	if err != nil {
+		return fmt.Errorf("include: %w", err)
+	}
+	var di diopts
+	for i, d := range descriptors {
+		factory, ok := c.modules[d.Module]
+		if !ok {
+			return fmt.Errorf("include: descriptor %d: unknown module %q", i, d.Module)
+		}
+		opt, err := factory(d.Params)
+		if err != nil {
+			return fmt.Errorf("include: module %q: %w", d.Module, err)
+		}
+		if opt != nil {
+			opt.apply(&di)
+		}
+	}
+	for _, prefix := range namespaces {
+		di.namespace(prefix)
+	}
+	return c.apply(di)
+}
@@ -0,0 +1,131 @@
+package di_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestInclude(t *testing.T) {
+	type ServerParams struct {
+		Addr string `json:"addr"`
+	}
+	modules := di.Options(
+		di.RegisterModule("server", di.ModuleOf(func(params ServerParams) (di.Option, error) {
+			if params.Addr == "" {
+				return nil, errors.New("addr required")
+			}
+			return di.ProvideValue(&http.Server{Addr: params.Addr}), nil
+		})),
+		di.RegisterModule("mux", di.ModuleOf(func(params struct{}) (di.Option, error) {
+			return di.Provide(http.NewServeMux), nil
+		})),
+	)
+	wiring := `[{"module": "server", "params": {"addr": ":8080"}}, {"module": "mux"}]`
+
+	check := func(t *testing.T, c *di.Container) {
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server))
+		require.Equal(t, ":8080", server.Addr)
+		var mux *http.ServeMux
+		require.NoError(t, c.Resolve(&mux))
+	}
+
+	t.Run("embedded file system source", func(t *testing.T) {
+		fsys := fstest.MapFS{"wiring.json": &fstest.MapFile{Data: []byte(wiring)}}
+		c, err := di.New(
+			modules,
+			di.Include(di.FSSource(fsys, "wiring.json")),
+		)
+		require.NoError(t, err)
+		check(t, c)
+	})
+
+	t.Run("file source", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "wiring.json")
+		require.NoError(t, os.WriteFile(path, []byte(wiring), 0o600))
+		c, err := di.New(
+			modules,
+			di.Include(di.FileSource(path)),
+		)
+		require.NoError(t, err)
+		check(t, c)
+	})
+
+	t.Run("http source", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(wiring))
+		}))
+		defer srv.Close()
+		c, err := di.New(
+			modules,
+			di.Include(di.HTTPSource(srv.Client(), srv.URL)),
+		)
+		require.NoError(t, err)
+		check(t, c)
+	})
+
+	t.Run("child container inherits modules", func(t *testing.T) {
+		c, err := di.New(modules)
+		require.NoError(t, err)
+		child, err := c.NewChild(
+			di.Include(di.SourceFunc(func(ctx context.Context) ([]di.Descriptor, error) {
+				return []di.Descriptor{{Module: "mux"}}, nil
+			})),
+		)
+		require.NoError(t, err)
+		var mux *http.ServeMux
+		require.NoError(t, child.Resolve(&mux))
+	})
+
+	t.Run("included options qualified by instantiate", func(t *testing.T) {
+		fsys := fstest.MapFS{"wiring.json": &fstest.MapFile{Data: []byte(wiring)}}
+		c, err := di.New(
+			modules,
+			di.Instantiate(di.Options(di.Include(di.FSSource(fsys, "wiring.json"))), "public"),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server, di.Name("public")))
+		require.Equal(t, ":8080", server.Addr)
+		var mux *http.ServeMux
+		require.NoError(t, c.Resolve(&mux, di.Name("public")))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for wiring, expected := range map[string]string{
+			`[{"module": "unknown"}]`:                        `include: descriptor 0: unknown module "unknown"`,
+			`[{"module": "server"}]`:                         `include: module "server": addr required`,
+			`[{"module": "server", "params": {"port": 80}}]`: `include: module "server": decode params: json: unknown field "port"`,
+			`{"module": "server"}`:                           `include: decode descriptors:`,
+			`[{"module": "server", "params": {"addr": 80}}]`: `include: module "server": decode params:`,
+		} {
+			_, err := di.New(
+				modules,
+				di.Include(di.FSSource(fstest.MapFS{"wiring.json": &fstest.MapFile{Data: []byte(wiring)}}, "wiring.json")),
+			)
+			require.Error(t, err, wiring)
+			require.Contains(t, err.Error(), "source_test.go:")
+			require.Contains(t, err.Error(), expected)
+		}
+	})
+
+	t.Run("http source error status", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		defer srv.Close()
+		_, err := di.New(
+			di.Include(di.HTTPSource(nil, srv.URL)),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected status 404 Not Found")
+	})
+}
@@ -3,7 +3,6 @@ package di
 import (
 	"fmt"
 	"runtime"
-	"strings"
 )
 
 // stacktrace returns stacktrace call frame with skip.
@@ -14,7 +13,7 @@ func stacktrace(skip int) (frame callerFrame) {
 	}
 	f := runtime.FuncForPC(pc)
 	return callerFrame{
-		function: shortFuncName(f),
+		function: f.Name(),
 		file:     file,
 		line:     line,
 	}
@@ -22,6 +21,7 @@ func stacktrace(skip int) (frame callerFrame) {
 
 // callerFrame represents stacktrace frame.
 type callerFrame struct {
+	// function is a fully qualified name of function, e.g. "github.com/user/app/server.New"
 	function string
 	file     string
 	line     int
@@ -32,16 +32,10 @@ func (f callerFrame) Format(s fmt.State, c rune) {
 	_, _ = fmt.Fprintf(s, "%s:%d", f.file, f.line)
 }
 
-func shortFuncName(f *runtime.Func) string {
-	longName := f.Name()
-
-	withoutPath := longName[strings.LastIndex(longName, "/")+1:]
-	withoutPackage := withoutPath[strings.Index(withoutPath, ".")+1:]
-
-	shortName := withoutPackage
-	shortName = strings.Replace(shortName, "(", "", 1)
-	shortName = strings.Replace(shortName, "*", "", 1)
-	shortName = strings.Replace(shortName, ")", "", 1)
-
-	return shortName
+// source returns file:line of frame or empty string if frame is unknown.
+func (f callerFrame) source() string {
+	if f.file == "" {
+		return ""
+	}
+	return fmt.Sprint(f)
 }
@@ -0,0 +1,84 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Strict returns container option that enables strict mode. In strict mode provide options
+// that have no effect on definition cause error instead of being silently ignored.
+//
+//	container, err := di.New(
+//		di.Strict(),
+//		di.ProvideValue(config, di.CacheError()), // error: di.CacheError() has no effect on value
+//	)
+func Strict() Option {
+	return option(func(c *diopts) {
+		c.settings = append(c.settings, func(c *Container) {
+			c.strict = true
+		})
+	})
+}
+
+// checkOptions returns error if provide option has no effect on node in strict mode.
+func (c *Container) checkOptions(n *node, params ProvideParams) error {
+	if !c.strict {
+		return nil
+	}
+	_, value := n.compiler.(valueCompiler)
+	switch {
+	case params.Cache != nil && params.Lifetime != Cached:
+		return fmt.Errorf("%s: di.WithCache() has no effect on %s lifetime", n, params.Lifetime)
+	case value && params.Lifetime != Singleton:
+		return fmt.Errorf("%s: di.WithLifetime() has no effect on value", n)
+	case value && params.Eager:
+		return fmt.Errorf("%s: di.Eager() has no effect on value", n)
+	case value && params.CacheError:
+		return fmt.Errorf("%s: di.CacheError() has no effect on value", n)
+	case params.Eager && params.Lifetime != Singleton:
+		return fmt.Errorf("%s: di.Eager() has no effect on %s lifetime", n, params.Lifetime)
+	case value && (params.AllSetters || len(params.Setters) > 0):
+		return fmt.Errorf("%s: di.Setters() has no effect on value", n)
+	case !value && params.Copy != nil:
+		return fmt.Errorf("%s: di.Copy() has no effect on constructor", n)
+	case params.CacheError && !canFail(n):
+		return fmt.Errorf("%s: di.CacheError() has no effect on constructor without error result", n)
+	}
+	return nil
+}
+
+// checkResolve returns error if resolve options of type t are ambiguous in strict mode: name of
+// group or tag keys that no definition of type contains.
+func (c *Container) checkResolve(t reflect.Type, params ResolveParams) error {
+	if !c.strict {
+		return nil
+	}
+	nodes, ok := c.schema.list(t)
+	if !ok && t.Kind() == reflect.Slice {
+		if _, named := params.Tags["name"]; named {
+			return fmt.Errorf("%s%s: name has no effect on group, use tags of group members", t, params.Tags)
+		}
+		nodes, ok = c.schema.list(t.Elem())
+	}
+	if !ok {
+		return nil
+	}
+	for k := range params.Tags {
+		known := false
+		for _, n := range nodes {
+			if _, known = n.tags[k]; known {
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%s%s: unknown tag key %s", t, params.Tags, k)
+		}
+	}
+	return nil
+}
+
+// canFail returns true if node constructor can return error.
+func canFail(n *node) bool {
+	cmp, ok := n.compiler.(*constructorCompiler)
+	return !ok || cmp.typ == ctorValueError || cmp.typ == ctorValueCleanupError
+}
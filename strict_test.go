@@ -0,0 +1,133 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestStrict(t *testing.T) {
+	t.Run("options without effect ignored by default", func(t *testing.T) {
+		_, err := di.New(
+			di.ProvideValue(&http.Server{}, di.CacheError(), di.Eager()),
+			di.Provide(http.NewServeMux, di.WithLifetime(di.Transient), di.Eager()),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("relevant options allowed", func(t *testing.T) {
+		_, err := di.New(
+			di.Strict(),
+			di.ProvideValue(&http.Server{}, di.As(new(interface{ Close() error }))),
+			di.Provide(func() (*http.ServeMux, error) { return nil, errors.New("fail") }, di.CacheError()),
+			di.Provide(func() *http.Client { return &http.Client{} }, di.WithCache(di.NewSingletonCache())),
+		)
+		require.NoError(t, err)
+	})
+
+	for _, tt := range []struct {
+		name   string
+		option di.Option
+		err    string
+	}{
+		{
+			name:   "cache error on value",
+			option: di.ProvideValue(&http.Server{}, di.CacheError()),
+			err:    "*http.Server: di.CacheError() has no effect on value",
+		},
+		{
+			name:   "eager value",
+			option: di.ProvideValue(&http.Server{}, di.Eager()),
+			err:    "*http.Server: di.Eager() has no effect on value",
+		},
+		{
+			name:   "value lifetime",
+			option: di.ProvideValue(&http.Server{}, di.WithLifetime(di.Scoped)),
+			err:    "*http.Server: di.WithLifetime() has no effect on value",
+		},
+		{
+			name:   "cache error on constructor without error",
+			option: di.Provide(http.NewServeMux, di.CacheError()),
+			err:    "*http.ServeMux: di.CacheError() has no effect on constructor without error result",
+		},
+		{
+			name:   "eager transient",
+			option: di.Provide(http.NewServeMux, di.WithLifetime(di.Transient), di.Eager()),
+			err:    "*http.ServeMux: di.Eager() has no effect on transient lifetime",
+		},
+		{
+			name:   "cache overridden by lifetime",
+			option: di.Provide(http.NewServeMux, di.WithCache(di.NewSingletonCache()), di.WithLifetime(di.Scoped)),
+			err:    "*http.ServeMux: di.WithCache() has no effect on scoped lifetime",
+		},
+		{
+			name:   "copy of constructor result",
+			option: di.Provide(http.NewServeMux, di.Copy()),
+			err:    "*http.ServeMux: di.Copy() has no effect on constructor",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := di.New(di.Strict(), tt.option)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "strict_test.go:")
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	t.Run("child inherits strict mode", func(t *testing.T) {
+		c, err := di.New(di.Strict())
+		require.NoError(t, err)
+		_, err = c.NewChild(di.ProvideValue(&http.Server{}, di.CacheError()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "di.CacheError() has no effect on value")
+	})
+
+	t.Run("resolve options validated", func(t *testing.T) {
+		c, err := di.New(
+			di.Strict(),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "public", "port": "80"}),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "private"}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		require.NoError(t, c.Resolve(&server, di.Name("private")))
+		require.NoError(t, c.Resolve(&server, di.Tags{"port": "80"}))
+		var servers []*http.Server
+		require.NoError(t, c.Resolve(&servers, di.Tags{"port": "*"}))
+		require.Len(t, servers, 1)
+	})
+
+	t.Run("name of group cause error", func(t *testing.T) {
+		c, err := di.New(
+			di.Strict(),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "public"}),
+		)
+		require.NoError(t, err)
+		var servers []*http.Server
+		err = c.Resolve(&servers, di.Name("public"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "strict_test.go:")
+		require.Contains(t, err.Error(), "[]*http.Server[name:public]: name has no effect on group, use tags of group members")
+	})
+
+	t.Run("unknown tag key cause error", func(t *testing.T) {
+		c, err := di.New(
+			di.Strict(),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "public"}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server, di.Tags{"nmae": "public"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "strict_test.go:")
+		require.Contains(t, err.Error(), "*http.Server[nmae:public]: unknown tag key nmae")
+		var servers []*http.Server
+		err = c.Resolve(&servers, di.Tags{"port": "80"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "[]*http.Server[port:80]: unknown tag key port")
+	})
+}
@@ -0,0 +1,53 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// structure is a definition of struct type that built without constructor.
+type structure struct {
+	rt reflect.Type
+}
+
+// ProvideStruct returns container option that provides type of prototype without constructor.
+// The prototype is a struct or pointer to struct, its value is not used. New instance created
+// on each build and its fields filled from container: public fields of struct with di.Inject or
+// fields with di tag.
+//
+//	type Handler struct {
+//		Server *http.Server `di:""`
+//		Logger *log.Logger  `di:"optional"`
+//	}
+//
+//	container, err := di.New(
+//		di.ProvideStruct(&Handler{}, di.As(new(http.Handler))),
+//	)
+func ProvideStruct(prototype Value, options ...ProvideOption) Option {
+	frame := stacktrace(0)
+	return option(func(c *diopts) {
+		c.provides = append(c.provides, provideOptions{
+			frame,
+			&structure{rt: reflect.TypeOf(prototype)},
+			options,
+		})
+	})
+}
+
+// newStructNode creates node of struct definition.
+func newStructNode(st *structure) (*node, error) {
+	rt := st.rt
+	if rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt == nil || rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w, struct or pointer to struct required, got %s", ErrInvalidConstructor, st.rt)
+	}
+	return &node{
+		compiler: newTypeCompiler(st.rt),
+		rt:       st.rt,
+		tags:     Tags{},
+		rv:       new(reflect.Value),
+		tagged:   true,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package di_test
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestProvideStruct(t *testing.T) {
+	t.Run("fields with tag filled", func(t *testing.T) {
+		type Handler struct {
+			http.Handler
+			Server *http.Server `di:""`
+			Public *http.Server `di:"name=public"`
+			Logger *log.Logger  `di:"optional"`
+			Addr   string
+		}
+		server := &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(server, di.Tags{"name": "public"}),
+			di.ProvideStruct(&Handler{Addr: "ignored"}, di.As(new(http.Handler))),
+		)
+		require.NoError(t, err)
+		var handler *Handler
+		require.NoError(t, c.Resolve(&handler))
+		require.Same(t, server, handler.Server)
+		require.Same(t, server, handler.Public)
+		require.Nil(t, handler.Logger)
+		require.Empty(t, handler.Addr)
+		var iface http.Handler
+		require.NoError(t, c.Resolve(&iface))
+		require.Same(t, handler, iface)
+	})
+
+	t.Run("struct with di.Inject", func(t *testing.T) {
+		type Application struct {
+			di.Inject
+			Server *http.Server
+		}
+		server := &http.Server{}
+		c, err := di.New(
+			di.ProvideValue(server),
+			di.ProvideStruct(Application{}, di.WithLifetime(di.Transient)),
+		)
+		require.NoError(t, err)
+		var app Application
+		require.NoError(t, c.Resolve(&app))
+		require.Same(t, server, app.Server)
+	})
+
+	t.Run("dependencies checked", func(t *testing.T) {
+		type Handler struct {
+			Server *http.Server `di:""`
+		}
+		c, err := di.New(
+			di.ProvideStruct(&Handler{}),
+		)
+		require.NoError(t, err)
+		var handler *Handler
+		err = c.Resolve(&handler)
+		require.True(t, errors.Is(err, di.ErrTypeNotExists))
+		require.Contains(t, err.Error(), "struct_test.go:")
+		require.Len(t, c.Providers(), 1)
+		require.Equal(t, "*http.Server", c.Providers()[0].Dependencies[0].Type.String())
+	})
+
+	t.Run("non-struct prototype cause error", func(t *testing.T) {
+		_, err := di.New(
+			di.ProvideStruct(new(int)),
+		)
+		require.True(t, errors.Is(err, di.ErrInvalidConstructor))
+		require.Contains(t, err.Error(), "struct or pointer to struct required, got *int")
+		_, err = di.New(
+			di.ProvideStruct(nil),
+		)
+		require.True(t, errors.Is(err, di.ErrInvalidConstructor))
+	})
+}
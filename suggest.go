@@ -0,0 +1,53 @@
+package di
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is a maximum count of suggestions in error message.
+const maxSuggestions = 3
+
+// suggest returns suggestions of definitions that could be meant instead of not found type t,
+// e.g. the same type with other tags, pointer or similarly named type.
+func (s *defaultSchema) suggest(t reflect.Type) string {
+	seen := map[string]bool{}
+	var suggestions []string
+	for _, n := range s.all() {
+		if n.implicit || seen[n.String()] || !similar(t, n.rt) {
+			continue
+		}
+		seen[n.String()] = true
+		suggestions = append(suggestions, n.String())
+	}
+	if len(suggestions) == 0 {
+		return ""
+	}
+	sort.Strings(suggestions)
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return "; did you mean " + strings.Join(suggestions, " or ") + "?"
+}
+
+// similar checks that type rt could be meant instead of type t.
+func similar(t, rt reflect.Type) bool {
+	switch {
+	case t == rt:
+		return true
+	case reflect.PtrTo(t) == rt, reflect.PtrTo(rt) == t:
+		return true
+	case t.Kind() == reflect.Interface && t.NumMethod() > 0 && rt.Implements(t):
+		return true
+	}
+	return typeName(t) != "" && typeName(t) == typeName(rt)
+}
+
+// typeName returns name of type or type that pointer points to.
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
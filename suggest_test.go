@@ -0,0 +1,58 @@
+package di_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestContainer_Suggestions(t *testing.T) {
+	t.Run("type with other tags suggested", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "api"}),
+			di.ProvideValue(&http.Server{}, di.Tags{"name": "admin"}),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server, di.Name("apii"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type *http.Server[name:apii] not exists in the container; did you mean *http.Server[name:admin] or *http.Server[name:api]?")
+	})
+
+	t.Run("pointer suggested", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}),
+		)
+		require.NoError(t, err)
+		var server http.Server
+		err = c.Resolve(&server)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type http.Server not exists in the container; did you mean *http.Server?")
+	})
+
+	t.Run("implementation suggested", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux),
+			di.Provide(func(handler http.Handler) *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		var server *http.Server
+		err = c.Resolve(&server)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "*http.Server: type http.Handler not exists in the container; did you mean *http.ServeMux?")
+	})
+
+	t.Run("no suggestions", func(t *testing.T) {
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}),
+		)
+		require.NoError(t, err)
+		var client *http.Client
+		err = c.Resolve(&client)
+		require.Error(t, err)
+		require.Regexp(t, `type \*http.Client not exists in the container$`, err.Error())
+	})
+}
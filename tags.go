@@ -1,6 +1,7 @@
 package di
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"strings"
@@ -70,6 +71,47 @@ func (t Tags) String() string {
 	return "[" + strings.Join(keys, ";") + "]"
 }
 
+// Merge returns new tags that contains key value pairs of t and others. Values of later tags
+// override previous.
+func (t Tags) Merge(others ...Tags) Tags {
+	result := Tags{}
+	for k, v := range t {
+		result[k] = v
+	}
+	for _, other := range others {
+		for k, v := range other {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// validate checks that tag keys can be used in selectors.
+func (t Tags) validate() error {
+	var keys []string
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := validateTagKey(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTagKey checks that key can be used in selectors.
+func validateTagKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("invalid tag key: empty")
+	}
+	if strings.ContainsAny(key, " \t,=!()") {
+		return fmt.Errorf("invalid tag key %q: contains one of reserved characters ' ,=!()'", key)
+	}
+	return nil
+}
+
 // match checks that all of key value pairs exists in t. Not equal.
 func (t Tags) match(tags Tags) bool {
 	for k, v := range tags {
@@ -86,13 +128,3 @@ func (t Tags) match(tags Tags) bool {
 	}
 	return true
 }
-
-func matchTags(nodes []*node, tags Tags) []*node {
-	matched := make([]*node, 0, 1)
-	for i := 0; i < len(nodes); i++ {
-		if nodes[i].tags.match(tags) {
-			matched = append(matched, nodes[i])
-		}
-	}
-	return matched
-}
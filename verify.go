@@ -0,0 +1,50 @@
+package di
+
+import (
+	"sort"
+	"strings"
+)
+
+// VerificationError is an error of container verification. It contains all found problems.
+type VerificationError struct {
+	// Problems is a list of found problems sorted by definition.
+	Problems []error
+}
+
+// Error is a string representation of verification error.
+func (e *VerificationError) Error() string {
+	messages := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		messages = append(messages, problem.Error())
+	}
+	return "verification failed:\n\t" + strings.Join(messages, "\n\t")
+}
+
+// Verify walks dependency graph of all definitions and reports missing dependencies, cycles
+// and ambiguous bindings without calling constructors. The error is a *VerificationError.
+//
+//	if err := container.Verify(); err != nil {
+//		log.Fatal(err)
+//	}
+func (c *Container) Verify() error {
+	var nodes []*node
+	for _, n := range c.schema.all() {
+		if n.implicit {
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].String() < nodes[j].String()
+	})
+	var problems []error
+	for _, n := range nodes {
+		if err := c.schema.prepare(n); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errWithStack(&VerificationError{Problems: problems})
+}
@@ -0,0 +1,51 @@
+package di_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestContainer_Verify(t *testing.T) {
+	t.Run("valid graph", func(t *testing.T) {
+		created := false
+		c, err := di.New(
+			di.Provide(func() *http.ServeMux {
+				created = true
+				return &http.ServeMux{}
+			}, di.As(new(http.Handler))),
+			di.Provide(func(handler http.Handler) *http.Server { return &http.Server{Handler: handler} }),
+		)
+		require.NoError(t, err)
+		require.NoError(t, c.Verify())
+		require.False(t, created)
+	})
+
+	t.Run("all problems reported", func(t *testing.T) {
+		type Cycle struct{}
+		c, err := di.New(
+			// missing dependency
+			di.Provide(func(client *http.Client) *http.Server { return &http.Server{} }),
+			// ambiguous interface binding
+			di.Provide(http.NewServeMux, di.As(new(http.Handler))),
+			di.Provide(func() http.HandlerFunc { return nil }, di.As(new(http.Handler))),
+			di.Provide(func(handler http.Handler) *http.Cookie { return &http.Cookie{} }),
+			// cycle
+			di.Provide(func(c *Cycle) *Cycle { return c }),
+		)
+		require.NoError(t, err)
+		err = c.Verify()
+		require.Error(t, err)
+		var verr *di.VerificationError
+		require.True(t, errors.As(err, &verr))
+		require.Len(t, verr.Problems, 3)
+		require.Contains(t, err.Error(), "verify_test.go:")
+		require.Contains(t, err.Error(), "*http.Cookie: multiple definitions of http.Handler")
+		require.Contains(t, err.Error(), "*http.Server: type *http.Client not exists in the container")
+		require.Contains(t, err.Error(), "cycle detected: *di_test.Cycle (")
+	})
+}
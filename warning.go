@@ -0,0 +1,171 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// WarningKind is a kind of non-fatal container finding.
+type WarningKind string
+
+const (
+	// WarningShadowed is a definition that shadows existing definition with the same type and tags.
+	// Shadowed definition can be resolved only as a part of group.
+	WarningShadowed WarningKind = "shadowed"
+	// WarningInheritedTags is a definition which provide tags override tags declared on its type.
+	WarningInheritedTags WarningKind = "inherited tags"
+	// WarningUnusedOptional is an optional field that was skipped because its type not found.
+	WarningUnusedOptional WarningKind = "unused optional"
+	// WarningSharedMutable is a singleton of mutable kind that consumed by many definitions.
+	// Reported in strict mode only.
+	WarningSharedMutable WarningKind = "shared mutable"
+)
+
+// Warning is a non-fatal finding collected by container during New(), Apply() and Resolve().
+type Warning struct {
+	// Kind is a kind of warning.
+	Kind WarningKind
+	// Type is a type that warning related to.
+	Type reflect.Type
+	// Message is a human readable description.
+	Message string
+}
+
+// String is a string representation of warning.
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Kind, w.Message)
+}
+
+// DuplicateRule specifies which definitions considered as duplicates. See di.Duplicates().
+type DuplicateRule int
+
+const (
+	// DuplicateTypeTags considers definitions with the same type and tags as duplicates.
+	DuplicateTypeTags DuplicateRule = iota
+	// DuplicateTypeName considers definitions with the same type and name tag as duplicates.
+	DuplicateTypeName
+	// DuplicateType considers definitions with the same type as duplicates.
+	DuplicateType
+)
+
+// equal checks that nodes are duplicates by rule.
+func (r DuplicateRule) equal(a, b *node) bool {
+	if a.rt != b.rt {
+		return false
+	}
+	switch r {
+	case DuplicateTypeTags:
+		return a.tags.String() == b.tags.String()
+	case DuplicateTypeName:
+		return a.tags["name"] == b.tags["name"]
+	case DuplicateType:
+		return true
+	}
+	bug()
+	return false
+}
+
+// Warnings returns non-fatal findings collected by container in order of occurrence. The same
+// finding reported once.
+//
+//	for _, w := range container.Warnings() {
+//		log.Println(w)
+//	}
+func (c *Container) Warnings() []Warning {
+	return append([]Warning(nil), c.schema.warnings...)
+}
+
+// checkShadowing warns if node shadows existing definition. Duplicates detected by container
+// duplicate rule.
+func (c *Container) checkShadowing(n *node) {
+	nodes, _ := c.schema.list(n.rt)
+	for _, cur := range nodes {
+		// default definitions intended to be replaced
+		if cur == n || cur.implicit || cur.byDefault || n.byDefault || !c.duplicates.equal(cur, n) {
+			continue
+		}
+		// definitions of child container intended to override parent ones
+		if c.schema.fallback && cur.owner != c.schema {
+			continue
+		}
+		c.schema.warn(Warning{
+			Kind:    WarningShadowed,
+			Type:    n.rt,
+			Message: fmt.Sprintf("%s provided at %s shadows definition provided at %s", n, n.frame, cur.frame),
+		})
+		return
+	}
+}
+
+// checkInheritedTags warns if provide tags override tags declared on the type.
+func checkInheritedTags(s schema, n *node, tags Tags) {
+	for k, v := range tags {
+		if declared, ok := n.tags[k]; ok && declared != v {
+			s.warn(Warning{
+				Kind:    WarningInheritedTags,
+				Type:    n.rt,
+				Message: fmt.Sprintf("tag %s=%s declared on %s overridden with %s=%s at %s", k, declared, n.rt, k, v, n.frame),
+			})
+		}
+	}
+}
+
+// checkSharedMutable warns about singletons of mutable kind that declared as dependency by more
+// than one definition. Definitions provided with di.Copy() or di.SharedMutable() not reported.
+func (c *Container) checkSharedMutable() {
+	consumers := map[*node]int{}
+	for _, n := range c.schema.all() {
+		if n.implicit || n.origin != nil {
+			continue
+		}
+		seen := map[*node]bool{}
+		for _, dep := range n.dependencies {
+			target, err := c.schema.find(dep.Type, dep.Tags)
+			if err != nil {
+				continue
+			}
+			target = target.instance()
+			if seen[target] || target.owner == nil {
+				continue
+			}
+			seen[target] = true
+			consumers[target]++
+		}
+	}
+	var shared []*node
+	for n, count := range consumers {
+		if count > 1 && n.lifetime == Singleton && !n.sharedMutable && isMutable(n.rt) {
+			shared = append(shared, n)
+		}
+	}
+	sort.Slice(shared, func(i, j int) bool {
+		return shared[i].String() < shared[j].String()
+	})
+	for _, n := range shared {
+		c.schema.warn(Warning{
+			Kind:    WarningSharedMutable,
+			Type:    n.rt,
+			Message: fmt.Sprintf("%s provided at %s is mutable and shared by %d definitions, use di.Copy() or di.SharedMutable()", n, n.frame, consumers[n]),
+		})
+	}
+}
+
+// isMutable checks that instances of type can be mutated by consumers: maps, slices and
+// pointers to struct with exported fields.
+func isMutable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Map, reflect.Slice:
+		return true
+	case reflect.Ptr:
+		if t.Elem().Kind() != reflect.Struct {
+			return false
+		}
+		for i := 0; i < t.Elem().NumField(); i++ {
+			if t.Elem().Field(i).PkgPath == "" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package di_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestContainer_Warnings(t *testing.T) {
+	t.Run("container without findings has no warnings", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		require.Empty(t, c.Warnings())
+	})
+
+	t.Run("shadowed provider", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }),
+			di.Provide(func() *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		warnings := c.Warnings()
+		require.Len(t, warnings, 1)
+		require.Equal(t, di.WarningShadowed, warnings[0].Kind)
+		require.Contains(t, warnings[0].String(), "shadowed: *http.Server provided at ")
+		require.Contains(t, warnings[0].String(), "warning_test.go:")
+	})
+
+	t.Run("shadowed provider of parent", func(t *testing.T) {
+		parent, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }),
+		)
+		require.NoError(t, err)
+		child, err := di.New()
+		require.NoError(t, err)
+		require.NoError(t, child.AddParent(parent))
+		require.NoError(t, child.Provide(func() *http.Server { return &http.Server{} }))
+		require.Len(t, child.Warnings(), 1)
+		require.Empty(t, parent.Warnings())
+	})
+
+	t.Run("providers with different tags are not shadowed", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"name": "public"}),
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"name": "private"}),
+		)
+		require.NoError(t, err)
+		require.Empty(t, c.Warnings())
+	})
+
+	t.Run("inherited tags overridden", func(t *testing.T) {
+		type Server struct {
+			di.Tags `di:"type=public"`
+		}
+		c, err := di.New(
+			di.Provide(func() *Server { return &Server{} }, di.Tags{"type": "private"}),
+		)
+		require.NoError(t, err)
+		warnings := c.Warnings()
+		require.Len(t, warnings, 1)
+		require.Equal(t, di.WarningInheritedTags, warnings[0].Kind)
+		require.Contains(t, warnings[0].Message, "tag type=public declared on *di_test.Server overridden with type=private")
+	})
+
+	t.Run("unused optional field reported once", func(t *testing.T) {
+		type Server struct {
+			di.Inject
+			Mux *http.ServeMux `di:"optional"`
+		}
+		c, err := di.New(
+			di.Provide(func() *Server { return &Server{} }),
+		)
+		require.NoError(t, err)
+		var server *Server
+		require.NoError(t, c.Resolve(&server))
+		var another Server
+		require.NoError(t, c.Resolve(&another))
+		warnings := c.Warnings()
+		require.Len(t, warnings, 1)
+		require.Equal(t, di.WarningUnusedOptional, warnings[0].Kind)
+		require.Equal(t, "optional field *http.ServeMux of di_test.Server skipped", warnings[0].Message)
+	})
+
+	t.Run("duplicates by name", func(t *testing.T) {
+		c, err := di.New(
+			di.Duplicates(di.DuplicateTypeName),
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"name": "public", "port": "80"}),
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"name": "public", "port": "443"}),
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"name": "private"}),
+		)
+		require.NoError(t, err)
+		warnings := c.Warnings()
+		require.Len(t, warnings, 1)
+		require.Contains(t, warnings[0].Message, "*http.Server[name:public;port:443] provided at")
+	})
+
+	t.Run("duplicates by type", func(t *testing.T) {
+		c, err := di.New(
+			di.Duplicates(di.DuplicateType),
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"name": "public"}),
+			di.Provide(func() *http.Server { return &http.Server{} }, di.Tags{"name": "private"}),
+		)
+		require.NoError(t, err)
+		require.Len(t, c.Warnings(), 1)
+	})
+
+	t.Run("shared mutable singletons reported in strict mode", func(t *testing.T) {
+		type Config struct {
+			Addr string
+		}
+		type Servers []*http.Server
+		options := di.Options(
+			di.ProvideValue(&Config{}),
+			di.ProvideValue(map[string]string{}, di.SharedMutable()),
+			di.ProvideValue(Servers{}, di.Copy()),
+			di.Provide(func(config *Config, m map[string]string, servers Servers) *http.Server { return &http.Server{} }),
+			di.Provide(func(config *Config, m map[string]string, servers Servers) *http.ServeMux { return http.NewServeMux() }),
+			di.Provide(func(config *Config) *http.Client { return &http.Client{} }, di.WithLifetime(di.Transient)),
+		)
+		c, err := di.New(options)
+		require.NoError(t, err)
+		require.Empty(t, c.Warnings())
+		c, err = di.New(di.Strict(), options)
+		require.NoError(t, err)
+		warnings := c.Warnings()
+		require.Len(t, warnings, 1)
+		require.Equal(t, di.WarningSharedMutable, warnings[0].Kind)
+		require.Contains(t, warnings[0].Message, "*di_test.Config provided at")
+		require.Contains(t, warnings[0].Message, "is mutable and shared by 3 definitions, use di.Copy() or di.SharedMutable()")
+	})
+
+	t.Run("immutable singletons are not reported", func(t *testing.T) {
+		type Config struct {
+			addr string
+		}
+		c, err := di.New(
+			di.Strict(),
+			di.ProvideValue(&Config{}),
+			di.ProvideValue(Config{}),
+			di.Provide(func(config *Config, value Config) *http.Server { return &http.Server{} }),
+			di.Provide(func(config *Config, value Config) *http.ServeMux { return http.NewServeMux() }),
+		)
+		require.NoError(t, err)
+		require.Empty(t, c.Warnings())
+	})
+}
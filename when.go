@@ -0,0 +1,56 @@
+package di
+
+// When returns provide option that provides type only when predicate returns true. The
+// predicate called on provide with container that has definitions provided before, so it
+// can check feature flags, environment or presence of other types.
+//
+//	container, err := di.New(
+//		di.Provide(NewConfig),
+//		di.Provide(NewTracer, di.When(func(c *di.Container) bool {
+//			has, _ := c.Has(new(*TracingConfig))
+//			return has
+//		})),
+//	)
+func When(predicate func(c *Container) bool) ProvideOption {
+	return provideOption(func(params *ProvideParams) {
+		params.predicates = append(params.predicates, predicate)
+	})
+}
+
+// ProvideIf returns container option that provides constructor only if cond is true. Unlike
+// di.When() the condition evaluated before container creation.
+//
+//	container, err := di.New(
+//		di.ProvideIf(os.Getenv("PROFILING") != "", NewProfiler),
+//	)
+func ProvideIf(cond bool, constructor Constructor, options ...ProvideOption) Option {
+	frame := stacktrace(0)
+	return option(func(c *diopts) {
+		if !cond {
+			return
+		}
+		c.provides = append(c.provides, provideOptions{
+			frame,
+			constructor,
+			options,
+		})
+	})
+}
+
+// satisfies checks that conditions of provide params hold.
+func (c *Container) satisfies(params ProvideParams) (bool, error) {
+	if len(params.profiles) > 0 && !c.schema.activeProfile(params.profiles) {
+		return false, nil
+	}
+	if params.condition != "" {
+		if ok, err := c.evaluate(params.condition); err != nil || !ok {
+			return false, err
+		}
+	}
+	for _, predicate := range params.predicates {
+		if !predicate(c) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,71 @@
+package di_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+)
+
+func TestWhen(t *testing.T) {
+	t.Run("provided when predicate holds", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(http.NewServeMux),
+			di.Provide(func(mux *http.ServeMux) *http.Server {
+				return &http.Server{Handler: mux}
+			}, di.When(func(c *di.Container) bool {
+				has, _ := c.Has(new(*http.ServeMux))
+				return has
+			})),
+		)
+		require.NoError(t, err)
+		has, err := c.Has(new(*http.Server))
+		require.NoError(t, err)
+		require.True(t, has)
+	})
+
+	t.Run("not provided when predicate fails", func(t *testing.T) {
+		c, err := di.New(
+			di.Provide(func() *http.Server { return &http.Server{} }, di.When(func(c *di.Container) bool {
+				has, _ := c.Has(new(*http.ServeMux))
+				return has
+			})),
+		)
+		require.NoError(t, err)
+		has, err := c.Has(new(*http.Server))
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("all predicates must hold", func(t *testing.T) {
+		yes := func(c *di.Container) bool { return true }
+		no := func(c *di.Container) bool { return false }
+		c, err := di.New(
+			di.ProvideValue(&http.Server{}, di.When(yes), di.When(no)),
+			di.ProvideValue(&http.Client{}, di.When(yes), di.When(yes)),
+		)
+		require.NoError(t, err)
+		has, err := c.Has(new(*http.Server))
+		require.NoError(t, err)
+		require.False(t, has)
+		has, err = c.Has(new(*http.Client))
+		require.NoError(t, err)
+		require.True(t, has)
+	})
+}
+
+func TestProvideIf(t *testing.T) {
+	c, err := di.New(
+		di.ProvideIf(true, http.NewServeMux),
+		di.ProvideIf(false, func() *http.Server { return &http.Server{} }),
+	)
+	require.NoError(t, err)
+	has, err := c.Has(new(*http.ServeMux))
+	require.NoError(t, err)
+	require.True(t, has)
+	has, err = c.Has(new(*http.Server))
+	require.NoError(t, err)
+	require.False(t, has)
+}
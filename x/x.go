@@ -0,0 +1,96 @@
+// Package x contains experimental features of di. The features are real implementations built
+// on stable core API, so they can be adopted before they freeze. API of this package can change
+// in minor releases. Stabilized features move into core package and remain here as deprecated
+// aliases for one minor release.
+//
+//	c, err := di.New(
+//		x.ProvideLifecycle(),
+//		di.Provide(NewServer),
+//		di.Invoke(func(lc *x.Lifecycle, server *http.Server) {
+//			lc.Append(x.Hook{
+//				OnStart: func(ctx context.Context) error { go server.ListenAndServe(); return nil },
+//				OnStop:  server.Shutdown,
+//			})
+//		}),
+//	)
+package x
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goava/di"
+)
+
+// Hook is a pair of functions that called on application start and stop. Both are optional.
+type Hook struct {
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle collects hooks of application components. It is not safe for concurrent use.
+type Lifecycle struct {
+	hooks []Hook
+	// started is a count of hooks which OnStart succeeded
+	started int
+}
+
+// ProvideLifecycle returns container option that provides *Lifecycle.
+func ProvideLifecycle() di.Option {
+	return di.Provide(func() *Lifecycle { return &Lifecycle{} })
+}
+
+// Append appends hook. Hooks started in order of appending and stopped in reverse order.
+func (l *Lifecycle) Append(hook Hook) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// Start calls OnStart of hooks in order of appending. If hook fails, already started hooks are
+// stopped and error returned.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	for l.started < len(l.hooks) {
+		hook := l.hooks[l.started]
+		if hook.OnStart != nil {
+			if err := hook.OnStart(ctx); err != nil {
+				if stopErr := l.Stop(ctx); stopErr != nil {
+					return fmt.Errorf("x: start: %w, stop: %s", err, stopErr)
+				}
+				return fmt.Errorf("x: start: %w", err)
+			}
+		}
+		l.started++
+	}
+	return nil
+}
+
+// Stop calls OnStop of started hooks in reverse order. All hooks called even some of them
+// fail, the first error returned.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	var first error
+	for ; l.started > 0; l.started-- {
+		hook := l.hooks[l.started-1]
+		if hook.OnStop == nil {
+			continue
+		}
+		if err := hook.OnStop(ctx); err != nil && first == nil {
+			first = fmt.Errorf("x: stop: %w", err)
+		}
+	}
+	return first
+}
+
+// RunScoped creates scope of container c with options, invokes fn in it and cleanups the scope.
+// Definitions provided by di.FromCtx() resolve values of ctx.
+//
+//	err := x.RunScoped(ctx, c, func(job *JobLogger) error {
+//		return job.Run()
+//	}, di.ProvideValue(job))
+func RunScoped(ctx context.Context, c *di.Container, fn di.Invocation, options ...di.Option) error {
+	ctx, end, err := c.Scope(ctx, options...)
+	if err != nil {
+		return err
+	}
+	defer end()
+	scope, _ := di.FromContext(ctx)
+	return scope.Invoke(fn)
+}
@@ -0,0 +1,73 @@
+package x_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goava/di"
+	"github.com/goava/di/x"
+)
+
+func TestLifecycle(t *testing.T) {
+	t.Run("hooks started in order and stopped in reverse order", func(t *testing.T) {
+		var calls []string
+		c, err := di.New(
+			x.ProvideLifecycle(),
+			di.Invoke(func(lc *x.Lifecycle) {
+				for _, name := range []string{"first", "second"} {
+					name := name
+					lc.Append(x.Hook{
+						OnStart: func(ctx context.Context) error { calls = append(calls, "start "+name); return nil },
+						OnStop:  func(ctx context.Context) error { calls = append(calls, "stop "+name); return nil },
+					})
+				}
+				lc.Append(x.Hook{})
+			}),
+		)
+		require.NoError(t, err)
+		var lc *x.Lifecycle
+		require.NoError(t, c.Resolve(&lc))
+		require.NoError(t, lc.Start(context.Background()))
+		require.NoError(t, lc.Stop(context.Background()))
+		require.NoError(t, lc.Stop(context.Background()))
+		require.Equal(t, []string{"start first", "start second", "stop second", "stop first"}, calls)
+	})
+
+	t.Run("failed start stops started hooks", func(t *testing.T) {
+		var calls []string
+		lc := &x.Lifecycle{}
+		lc.Append(x.Hook{
+			OnStop: func(ctx context.Context) error { calls = append(calls, "stop first"); return errors.New("stop error") },
+		})
+		lc.Append(x.Hook{
+			OnStart: func(ctx context.Context) error { return errors.New("start error") },
+			OnStop:  func(ctx context.Context) error { calls = append(calls, "stop second"); return nil },
+		})
+		err := lc.Start(context.Background())
+		require.EqualError(t, err, "x: start: start error, stop: x: stop: stop error")
+		require.Equal(t, []string{"stop first"}, calls)
+	})
+}
+
+func TestRunScoped(t *testing.T) {
+	type key struct{}
+	type RequestID string
+	c, err := di.New(
+		di.FromCtx[RequestID](key{}),
+	)
+	require.NoError(t, err)
+	var id RequestID
+	ctx := context.WithValue(context.Background(), key{}, RequestID("42"))
+	err = x.RunScoped(ctx, c, func(requestID RequestID, value string) error {
+		id = requestID
+		require.Equal(t, "value", value)
+		return nil
+	}, di.ProvideValue("value"))
+	require.NoError(t, err)
+	require.Equal(t, RequestID("42"), id)
+	err = x.RunScoped(ctx, c, func() error { return errors.New("invocation error") })
+	require.EqualError(t, err, "invocation error")
+}